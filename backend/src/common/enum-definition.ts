@@ -8,6 +8,7 @@ export enum WebsocketEvent {
   SCHEDULER = 'SCHEDULER',
   PERF_STAT = 'PERF_STAT',
   TURBO_STAT = 'TURBO_STAT',
+  MONAD_ANALYSIS = 'MONAD_ANALYSIS',
 }
 
 export enum NetworkEvent {
@@ -95,4 +95,9 @@ export class WebSocketHandler
     const doc = await this.appService.saveTurboStatEvent(data);
     this.sendToClient(WebsocketEvent.TURBO_STAT, doc);
   }
+
+  @SubscribeMessage(WebsocketEvent.MONAD_ANALYSIS)
+  handleMonadAnalysis(@MessageBody() data: any) {
+    this.sendToClient(WebsocketEvent.MONAD_ANALYSIS, data);
+  }
 }
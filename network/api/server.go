@@ -0,0 +1,286 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"monad-flow/tracker"
+	"monad-flow/util"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	defaultLocalAPIAddr = "127.0.0.1:8090"
+	maxLeaderCount      = 1000
+)
+
+type leaderEntry struct {
+	Epoch util.Epoch `json:"epoch"`
+	Round util.Round `json:"round"`
+	util.Validator
+}
+
+// Start 는 사이드카가 유지하는 상태를 조회할 수 있는 로컬 HTTP 엔드포인트를 띄웁니다.
+func Start(ctx context.Context, wg *sync.WaitGroup) {
+	addr := getLocalAPIAddr()
+	if addr == "no" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /peers", handlePeers)
+	mux.HandleFunc("GET /peers/{nodeID}", handlePeer)
+	mux.HandleFunc("GET /groups", handleGroups)
+	mux.HandleFunc("GET /leaders", handleLeaders)
+	mux.HandleFunc("GET /leaders/scores", handleLeaderScores)
+	mux.HandleFunc("GET /voters/latency", handleVoterLatency)
+	mux.HandleFunc("GET /validators/inferred", handleInferredValidators)
+	mux.HandleFunc("GET /propagation", handlePropagation)
+	mux.HandleFunc("GET /self", handleSelf)
+	mux.HandleFunc("GET /traffic/matrix", handleTrafficMatrix)
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Printf("[Local API] Listening on %s", addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("[Local API] Server error: %v", err)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		log.Println("[Local API] Shutting down.")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+}
+
+func handlePeers(w http.ResponseWriter, r *http.Request) {
+	peers := tracker.Peers()
+	if ip := r.URL.Query().Get("ip"); ip != "" {
+		filtered := peers[:0]
+		for _, peer := range peers {
+			if peer.IP == ip {
+				filtered = append(filtered, peer)
+			}
+		}
+		peers = filtered
+	}
+	writeJSON(w, http.StatusOK, peers)
+}
+
+func handlePeer(w http.ResponseWriter, r *http.Request) {
+	nodeID := strings.TrimPrefix(strings.ToLower(r.PathValue("nodeID")), "0x")
+	peer, ok := tracker.Peer(nodeID)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "peer not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, peer)
+}
+
+func handleGroups(w http.ResponseWriter, r *http.Request) {
+	roundStr := r.URL.Query().Get("round")
+	if roundStr == "" {
+		writeJSON(w, http.StatusOK, tracker.FullNodeGroups())
+		return
+	}
+	round, err := strconv.ParseUint(roundStr, 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid round"})
+		return
+	}
+	writeJSON(w, http.StatusOK, tracker.ActiveFullNodeGroups(util.Round(round)))
+}
+
+func handleLeaders(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	epoch, err := strconv.ParseUint(query.Get("epoch"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid epoch"})
+		return
+	}
+	round, err := strconv.ParseUint(query.Get("round"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid round"})
+		return
+	}
+	count := uint64(1)
+	if countStr := query.Get("count"); countStr != "" {
+		count, err = strconv.ParseUint(countStr, 10, 64)
+		if err != nil || count == 0 || count > maxLeaderCount {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid count"})
+			return
+		}
+	}
+
+	entries := make([]leaderEntry, 0, count)
+	for i := uint64(0); i < count; i++ {
+		leader, ok := tracker.ExpectedLeader(util.Epoch(epoch), util.Round(round+i))
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "no validator set for epoch"})
+			return
+		}
+		entries = append(entries, leaderEntry{Epoch: util.Epoch(epoch), Round: util.Round(round + i), Validator: leader})
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func handleLeaderScores(w http.ResponseWriter, r *http.Request) {
+	var epoch uint64
+	if epochStr := r.URL.Query().Get("epoch"); epochStr != "" {
+		var err error
+		epoch, err = strconv.ParseUint(epochStr, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid epoch"})
+			return
+		}
+	}
+	card, ok := tracker.LeaderScores(util.Epoch(epoch))
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "no scores for epoch"})
+		return
+	}
+	writeJSON(w, http.StatusOK, card)
+}
+
+func handleVoterLatency(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, tracker.VoterLatencies())
+}
+
+func handleInferredValidators(w http.ResponseWriter, r *http.Request) {
+	var epoch uint64
+	if epochStr := r.URL.Query().Get("epoch"); epochStr != "" {
+		var err error
+		epoch, err = strconv.ParseUint(epochStr, 10, 64)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid epoch"})
+			return
+		}
+	}
+	set, ok := tracker.InferredValidators(util.Epoch(epoch))
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "no signers observed for epoch"})
+		return
+	}
+	writeJSON(w, http.StatusOK, set)
+}
+
+func handlePropagation(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, tracker.Propagation())
+}
+
+func handleSelf(w http.ResponseWriter, r *http.Request) {
+	metrics, ok := tracker.Self()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "local node identity not configured"})
+		return
+	}
+	writeJSON(w, http.StatusOK, metrics)
+}
+
+// handleTrafficMatrix 는 마지막 구간의 행렬을 JSON 으로, format=csv 이면 metric(chunks|bytes) 기준의 CSV 행렬로 돌려줍니다.
+func handleTrafficMatrix(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	matrix, ok := tracker.LastTrafficMatrix()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "no traffic matrix yet"})
+		return
+	}
+	if query.Get("format") != "csv" {
+		writeJSON(w, http.StatusOK, matrix)
+		return
+	}
+
+	metric := query.Get("metric")
+	if metric == "" {
+		metric = "bytes"
+	}
+	if metric != "bytes" && metric != "chunks" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid metric"})
+		return
+	}
+
+	index := make(map[string]int, len(matrix.Nodes))
+	for i, node := range matrix.Nodes {
+		index[node.NodeID] = i
+	}
+	values := make([][]uint64, len(matrix.Nodes))
+	for i := range values {
+		values[i] = make([]uint64, len(matrix.Nodes))
+	}
+	for _, cell := range matrix.Cells {
+		from, ok := index[cell.From]
+		if !ok {
+			continue
+		}
+		to, ok := index[cell.To]
+		if !ok {
+			continue
+		}
+		value := cell.Bytes
+		if metric == "chunks" {
+			value = cell.Chunks
+		}
+		values[from][to] = value
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.WriteHeader(http.StatusOK)
+	writer := csv.NewWriter(w)
+	header := make([]string, 0, len(matrix.Nodes)+1)
+	header = append(header, "from\\to")
+	for _, node := range matrix.Nodes {
+		header = append(header, node.NodeID)
+	}
+	writer.Write(header)
+	for i, node := range matrix.Nodes {
+		row := make([]string, 0, len(matrix.Nodes)+1)
+		row = append(row, node.NodeID)
+		for _, value := range values[i] {
+			row = append(row, strconv.FormatUint(value, 10))
+		}
+		writer.Write(row)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("[Local API] Failed to write traffic matrix CSV: %v", err)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("[Local API] Failed to encode response: %v", err)
+	}
+}
+
+func getLocalAPIAddr() string {
+	godotenv.Load()
+	addr := os.Getenv("LOCAL_API_ADDR")
+	if addr == "" {
+		addr = defaultLocalAPIAddr
+	}
+	return addr
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"monad-flow/api"
 	"monad-flow/parser"
+	"monad-flow/publisher"
 	"monad-flow/tcp"
+	"monad-flow/tracker"
 	"monad-flow/udp"
 	"monad-flow/util"
 	"os"
@@ -62,6 +65,10 @@ func main() {
 		cancel()
 	}()
 
+	publisher.Start(ctx, &wg, client, &clientMutex)
+	tracker.Start(ctx, &wg)
+	api.Start(ctx, &wg)
+
 	// Initialize Managers
 	tcpManager := tcp.NewManager(ctx, &wg, client, &clientMutex)
 	tcpManager.Start()
@@ -28,7 +28,10 @@ type WireNameRecordV2 struct {
 type VersionedNameRecord interface {}
 
 type NameRecord struct {
+    Version uint8
     Record VersionedNameRecord
+
+    raw []byte
 }
 
 type MonadNameRecord struct {
@@ -43,26 +46,55 @@ func (nr *NameRecord) DecodeRLP(s *rlp.Stream) error {
         return fmt.Errorf("failed to read raw bytes: %w", err)
     }
 
-    // 2. V2 디코딩 시도 
-    var v2 WireNameRecordV2
-    if err := rlp.DecodeBytes(raw, &v2); err == nil {
-        if len(v2.IP) != 4 {
-             return fmt.Errorf("invalid V2 IPv4 length")
-        }
-        nr.Record = &v2
-        return nil
+    // 2. 리스트 원소 개수로 버전을 결정 (V1: 3개, V2: 4개)
+    content, _, err := rlp.SplitList(raw)
+    if err != nil {
+        return fmt.Errorf("NameRecord is not an RLP list: %w", err)
+    }
+    count, err := rlp.CountValues(content)
+    if err != nil {
+        return fmt.Errorf("failed to count NameRecord fields: %w", err)
     }
 
-    // 3. 실패 시 V1 디코딩 시도
-    var v1 WireNameRecordV1
-    if err := rlp.DecodeBytes(raw, &v1); err == nil {
+    switch count {
+    case 3:
+        var v1 WireNameRecordV1
+        if err := rlp.DecodeBytes(raw, &v1); err != nil {
+            return fmt.Errorf("failed to decode V1 NameRecord: %w", err)
+        }
         if len(v1.IP) != 4 {
              return fmt.Errorf("invalid V1 IPv4 length")
         }
+        nr.Version = util.NameRecordV1
         nr.Record = &v1
-        return nil
+
+    case 4:
+        var v2 WireNameRecordV2
+        if err := rlp.DecodeBytes(raw, &v2); err != nil {
+            return fmt.Errorf("failed to decode V2 NameRecord: %w", err)
+        }
+        if len(v2.IP) != 4 {
+             return fmt.Errorf("invalid V2 IPv4 length")
+        }
+        nr.Version = util.NameRecordV2
+        nr.Record = &v2
+
+    default:
+        return fmt.Errorf("unexpected NameRecord field count: %d", count)
     }
 
-    // 4. 둘 다 실패
-    return fmt.Errorf("failed to decode NameRecord (neither V1 nor V2)")
-}
\ No newline at end of file
+    nr.raw = raw
+    return nil
+}
+
+// RecoverNodeID 는 레코드 서명으로부터 레코드를 서명한 노드의 secp 공개키(hex)를 복구합니다.
+func (r *MonadNameRecord) RecoverNodeID() (string, error) {
+    if r.NameRecord == nil || len(r.NameRecord.raw) == 0 {
+        return "", fmt.Errorf("name record has no encoded body")
+    }
+    signer, err := util.RecoverSigner(util.NameRecordSigningPrefix, r.NameRecord.raw, r.Signature)
+    if err != nil {
+        return "", err
+    }
+    return signer.NodeID, nil
+}
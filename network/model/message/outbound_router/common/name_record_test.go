@@ -0,0 +1,122 @@
+package common
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"monad-flow/util"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/zeebo/blake3"
+)
+
+func TestNameRecordDecodeVersion(t *testing.T) {
+	v1, _ := rlp.EncodeToBytes(&WireNameRecordV1{IP: []byte{10, 0, 0, 1}, Port: 8000, Seq: 7})
+	v2, _ := rlp.EncodeToBytes(&WireNameRecordV2{
+		IP:           []byte{10, 0, 0, 2},
+		Ports:        []WirePort{{Tag: 0, Port: 8000}, {Tag: 1, Port: 8001}},
+		Capabilities: 1,
+		Seq:          9,
+	})
+	badIP, _ := rlp.EncodeToBytes(&WireNameRecordV1{IP: []byte{10, 0, 1}, Port: 8000, Seq: 7})
+	twoFields, _ := rlp.EncodeToBytes([]interface{}{[]byte{10, 0, 0, 1}, uint64(1)})
+
+	tests := []struct {
+		name    string
+		raw     []byte
+		version uint8
+		seq     uint64
+		wantErr bool
+	}{
+		{name: "v1 has 3 fields", raw: v1, version: util.NameRecordV1, seq: 7},
+		{name: "v2 has 4 fields", raw: v2, version: util.NameRecordV2, seq: 9},
+		{name: "short ip", raw: badIP, wantErr: true},
+		{name: "unknown field count", raw: twoFields, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var nr NameRecord
+			err := rlp.DecodeBytes(tt.raw, &nr)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got version %d", nr.Version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if nr.Version != tt.version {
+				t.Fatalf("version = %d, want %d", nr.Version, tt.version)
+			}
+			var seq uint64
+			switch record := nr.Record.(type) {
+			case *WireNameRecordV1:
+				seq = record.Seq
+			case *WireNameRecordV2:
+				seq = record.Seq
+			}
+			if seq != tt.seq {
+				t.Fatalf("seq = %d, want %d", seq, tt.seq)
+			}
+		})
+	}
+}
+
+func TestMonadNameRecordRecoverNodeID(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := hex.EncodeToString(crypto.CompressPubkey(&key.PublicKey))
+
+	raw, _ := rlp.EncodeToBytes(&WireNameRecordV1{IP: []byte{10, 0, 0, 1}, Port: 8000, Seq: 1})
+	sighash := blake3.Sum256(append([]byte(util.NameRecordSigningPrefix), raw...))
+	sig, err := crypto.Sign(sighash[:], key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, _ := rlp.EncodeToBytes([]interface{}{rlp.RawValue(raw), sig})
+
+	var record MonadNameRecord
+	if err := rlp.DecodeBytes(encoded, &record); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *MonadNameRecord)
+		want    string
+		wantErr bool
+	}{
+		{name: "signed record", want: want},
+		{name: "tampered signature", mutate: func(r *MonadNameRecord) {
+			r.Signature = append(util.Signature(nil), r.Signature...)
+			r.Signature[0] ^= 0xff
+		}},
+		{name: "short signature", mutate: func(r *MonadNameRecord) { r.Signature = r.Signature[:64] }, wantErr: true},
+		{name: "no body", mutate: func(r *MonadNameRecord) { r.NameRecord = nil }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := record
+			if tt.mutate != nil {
+				tt.mutate(&r)
+			}
+			got, err := r.RecoverNodeID()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %s", got)
+				}
+				return
+			}
+			if tt.want != "" && (err != nil || got != tt.want) {
+				t.Fatalf("RecoverNodeID() = %s, %v; want %s", got, err, tt.want)
+			}
+			if tt.want == "" && err == nil && got == want {
+				t.Fatalf("tampered record recovered the original signer")
+			}
+		})
+	}
+}
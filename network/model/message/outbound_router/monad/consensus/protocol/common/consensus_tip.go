@@ -52,8 +52,12 @@ type ConsensusBlockHeader struct {
 	BaseFee       *uint64 `rlp:"optional"`
 	BaseFeeTrend  *uint64 `rlp:"optional"`
 	BaseFeeMoment *uint64 `rlp:"optional"`
+
+	raw []byte
 }
 
+type consensusBlockHeaderRLP ConsensusBlockHeader
+
 type NoEndorsementCertificate struct {
 	Msg        *no_endorsement.NoEndorsement
 	Signatures []byte
@@ -86,3 +90,29 @@ func (ct *ConsensusTip) DecodeRLP(s *rlp.Stream) error {
 
 	return s.ListEnd()
 }
+
+func (h *ConsensusBlockHeader) DecodeRLP(s *rlp.Stream) error {
+	// BlockID 계산을 위해 수신한 인코딩을 그대로 보관합니다.
+	raw, err := s.Raw()
+	if err != nil {
+		return fmt.Errorf("failed to read ConsensusBlockHeader raw bytes: %w", err)
+	}
+	if err := rlp.DecodeBytes(raw, (*consensusBlockHeaderRLP)(h)); err != nil {
+		return err
+	}
+	h.raw = raw
+	return nil
+}
+
+// Raw 는 수신한 헤더의 RLP 인코딩입니다.
+func (h *ConsensusBlockHeader) Raw() []byte {
+	return h.raw
+}
+
+// BlockID 는 monad-bft 와 같이 헤더의 RLP 인코딩을 해시한 값입니다.
+func (h *ConsensusBlockHeader) BlockID() util.BlockID {
+	if len(h.raw) == 0 {
+		return util.BlockID{}
+	}
+	return util.HashEncoded(h.raw)
+}
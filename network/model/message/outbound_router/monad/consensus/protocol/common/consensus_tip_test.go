@@ -0,0 +1,60 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"monad-flow/util"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/zeebo/blake3"
+)
+
+func encodeHeader(t *testing.T, round util.Round, seqNum util.SeqNum) []byte {
+	t.Helper()
+	h := consensusBlockHeaderRLP{BlockRound: round, Epoch: 1, SeqNum: seqNum, Author: make([]byte, 33)}
+	h.QC.Signatures = rlp.RawValue{0xc0}
+	raw, err := rlp.EncodeToBytes(&h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestConsensusBlockHeaderBlockID(t *testing.T) {
+	first := encodeHeader(t, 5, 3)
+	second := encodeHeader(t, 6, 4)
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: "round 5", raw: first},
+		{name: "round 6", raw: second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h ConsensusBlockHeader
+			if err := rlp.DecodeBytes(tt.raw, &h); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if !bytes.Equal(h.Raw(), tt.raw) {
+				t.Fatalf("Raw() does not keep the received encoding")
+			}
+			want := util.BlockID(blake3.Sum256(tt.raw))
+			if got := h.BlockID(); got != want {
+				t.Fatalf("BlockID() = %s, want %s", got.Hex(), want.Hex())
+			}
+		})
+	}
+
+	var a, b ConsensusBlockHeader
+	rlp.DecodeBytes(first, &a)
+	rlp.DecodeBytes(second, &b)
+	if a.BlockID() == b.BlockID() {
+		t.Fatalf("different headers share a BlockID")
+	}
+	if (&ConsensusBlockHeader{}).BlockID() != (util.BlockID{}) {
+		t.Fatalf("header without an encoding should have a zero BlockID")
+	}
+}
@@ -1,10 +1,12 @@
 package proposal
 
 import (
+	"fmt"
 	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
 	"monad-flow/util"
 
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 type Ommer struct{}
@@ -21,8 +23,53 @@ type ProposalMessage struct {
 	Tip           *common.ConsensusTip
 	BlockBody     *ConsensusBlockBody
 	LastRoundTC   *common.TimeoutCertificate `rlp:"optional"`
+
+	// 디코딩 후 계산되는 값 (RLP 에는 없음)
+	BlockID        util.BlockID `rlp:"-"`
+	BlockBodyValid bool         `rlp:"-"`
 }
 
+type proposalMessageRLP ProposalMessage
+
 type ConsensusBlockBody struct {
 	ExecutionBody ExecutionBody
+
+	raw []byte
+}
+
+type consensusBlockBodyRLP ConsensusBlockBody
+
+func (p *ProposalMessage) DecodeRLP(s *rlp.Stream) error {
+	if err := s.Decode((*proposalMessageRLP)(p)); err != nil {
+		return err
+	}
+
+	if p.Tip != nil && p.Tip.BlockHeader != nil {
+		p.BlockID = p.Tip.BlockHeader.BlockID()
+		if p.BlockBody != nil {
+			p.BlockBodyValid = p.BlockBody.BodyID() == p.Tip.BlockHeader.BlockBodyID
+		}
+	}
+	return nil
+}
+
+func (b *ConsensusBlockBody) DecodeRLP(s *rlp.Stream) error {
+	// BodyID 계산을 위해 수신한 인코딩을 그대로 보관합니다.
+	raw, err := s.Raw()
+	if err != nil {
+		return fmt.Errorf("failed to read ConsensusBlockBody raw bytes: %w", err)
+	}
+	if err := rlp.DecodeBytes(raw, (*consensusBlockBodyRLP)(b)); err != nil {
+		return err
+	}
+	b.raw = raw
+	return nil
+}
+
+// BodyID 는 헤더의 BlockBodyID 와 비교할 수 있도록 body 의 RLP 인코딩을 해시한 값입니다.
+func (b *ConsensusBlockBody) BodyID() util.ConsensusBlockBodyId {
+	if len(b.raw) == 0 {
+		return util.ConsensusBlockBodyId{}
+	}
+	return util.HashEncoded(b.raw)
 }
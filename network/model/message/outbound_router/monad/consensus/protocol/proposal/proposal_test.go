@@ -0,0 +1,42 @@
+package proposal
+
+import (
+	"testing"
+
+	"monad-flow/util"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/zeebo/blake3"
+)
+
+func TestConsensusBlockBodyBodyID(t *testing.T) {
+	empty, _ := rlp.EncodeToBytes(&consensusBlockBodyRLP{})
+	withdrawal, _ := rlp.EncodeToBytes(&consensusBlockBodyRLP{ExecutionBody: ExecutionBody{
+		Withdrawals: []*types.Withdrawal{{Index: 1, Validator: 2, Amount: 3}},
+	}})
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: "empty body", raw: empty},
+		{name: "body with withdrawal", raw: withdrawal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body ConsensusBlockBody
+			if err := rlp.DecodeBytes(tt.raw, &body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			want := util.ConsensusBlockBodyId(blake3.Sum256(tt.raw))
+			if got := body.BodyID(); got != want {
+				t.Fatalf("BodyID() = %x, want %x", got, want)
+			}
+		})
+	}
+
+	if (&ConsensusBlockBody{}).BodyID() != (util.ConsensusBlockBodyId{}) {
+		t.Fatalf("body without an encoding should have a zero BodyID")
+	}
+}
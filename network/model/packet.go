@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"monad-flow/model/message/outbound_router"
+	"time"
 
 	"github.com/google/gopacket/layers"
 )
@@ -54,12 +55,23 @@ type Packet struct {
 	Payload       []byte
 }
 
+// MessageMeta 는 디코딩된 메시지가 어디서, 언제, 누구로부터 왔는지를 담습니다.
+type MessageMeta struct {
+	AppMessageHash string
+	SrcIP          string
+	DstIP          string
+	Author         string // 청크 서명에서 복구한 secp 공개키 (TCP 는 빈 값)
+	CaptureTime    time.Time
+	Size           int // 디코딩 전 메시지 바이트 수
+}
+
 type OutboundRouterCombined struct {
 	Version        outbound_router.NetworkMessageVersion `json:"version"`
 	MessageType    uint8                                 `json:"messageType"`
 	PeerDiscovery  interface{}                           `json:"peerDiscovery,omitempty"`
 	FullNodesGroup interface{}                           `json:"fullNodesGroup,omitempty"`
 	AppMessage     interface{}                           `json:"appMessage,omitempty"`
+	Transactions   interface{}                           `json:"transactions,omitempty"`
 }
 
 func (packet *MonadChunkPacket) PrintMonadPacketDetails() {
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"monad-flow/tracker"
+	"monad-flow/util"
+)
+
+// 관측된 제안 라운드부터 이만큼 앞의 라운드까지만 리더를 백엔드에 올립니다.
+const leaderLookahead = 20
+
+var (
+	leaderPostMu      sync.Mutex
+	postedLeaderRound util.Round
+	postedLeaderEpoch util.Epoch
+)
+
+// postUpcomingLeaders 는 아직 올리지 않은 [round, round+leaderLookahead] 구간의 리더를 전송합니다.
+// 이미 다른 고루틴이 전송 중이면 건너뛰고, 다음 제안에서 이어서 보냅니다.
+// 검증자 집합이 없는 에포크라면 거기서 멈추므로, 파일이 갱신된 뒤 같은 라운드부터 다시 시도합니다.
+// 에포크가 끝나는 라운드는 알 수 없어서 앞선 라운드는 현재 에포크로 계산해 올리고,
+// 새 에포크의 제안이 처음 보이면 그 라운드부터 새 검증자 집합으로 다시 올려 덮어씁니다.
+func postUpcomingLeaders(epoch util.Epoch, round util.Round) {
+	if !leaderPostMu.TryLock() {
+		return
+	}
+	defer leaderPostMu.Unlock()
+
+	if epoch < postedLeaderEpoch {
+		return
+	}
+	from := round
+	if epoch == postedLeaderEpoch && postedLeaderRound >= from {
+		from = postedLeaderRound + 1
+	}
+	for r := from; r <= round+leaderLookahead; r++ {
+		leader, ok := tracker.ExpectedLeader(epoch, r)
+		if !ok {
+			return
+		}
+		sendLeaderPayload(epoch, r, leader)
+		postedLeaderRound = r
+		postedLeaderEpoch = epoch
+	}
+}
+
+func sendLeaderPayload(epoch util.Epoch, round util.Round, leader util.Validator) {
+	payload := map[string]interface{}{
+		"epoch":       epoch,
+		"round":       round,
+		"node_id":     leader.NodeID,
+		"cert_pubkey": leader.CertPubkey,
+		"stake":       leader.Stake,
+		"timestamp":   time.Now().Format("2006-01-02 15:04:05.000000"),
+	}
+
+	finalBody, err := json.Marshal(payload)
+	if err != nil {
+		fmt.Printf("Error marshaling final payload: %v\n", err)
+		return
+	}
+
+	resp, err := httpClient.Post(LeaderAPIURL, "application/json", bytes.NewBuffer(finalBody))
+	if err != nil {
+		fmt.Printf("Failed to send to backend: %v\n", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		fmt.Printf("Backend returned non-OK status: %s\n", resp.Status)
+		return
+	}
+}
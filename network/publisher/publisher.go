@@ -0,0 +1,62 @@
+package publisher
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"monad-flow/util"
+
+	"github.com/zishang520/socket.io/clients/socket/v3"
+)
+
+var (
+	eventChan   chan map[string]interface{}
+	client      *socket.Socket
+	clientMutex *sync.Mutex
+)
+
+// Start 는 사이드카가 직접 계산한 분석 이벤트를 백엔드로 내보내는 워커를 띄웁니다.
+func Start(ctx context.Context, wg *sync.WaitGroup, c *socket.Socket, mu *sync.Mutex) {
+	client = c
+	clientMutex = mu
+	eventChan = make(chan map[string]interface{}, 10000)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("[Publisher] Started.")
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("[Publisher] Shutting down.")
+				return
+			case payload := <-eventChan:
+				clientMutex.Lock()
+				if client != nil {
+					(*client).Emit(util.MONAD_ANALYSIS_EVENT, payload)
+				}
+				clientMutex.Unlock()
+			}
+		}
+	}()
+}
+
+func Publish(eventType int, data interface{}) {
+	if eventChan == nil {
+		return
+	}
+
+	payload := map[string]interface{}{
+		"type":      eventType,
+		"data":      data,
+		"timestamp": time.Now().UnixMicro(),
+	}
+
+	select {
+	case eventChan <- payload:
+	default:
+		log.Println("[WARN] Publisher channel full, dropping event")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"log"
+	"monad-flow/model"
 	"monad-flow/model/message/outbound_router/monad/common"
 	"monad-flow/parser"
 	"sync"
@@ -100,7 +101,14 @@ func (s *MonadTcpStream) run() {
 				return
 			}
 		}
-		if err := parser.HandleDecodedMessage(signedMsg.Payload, "none"); err != nil {
+		meta := model.MessageMeta{
+			AppMessageHash: "none",
+			SrcIP:          s.net.Src().String(),
+			DstIP:          s.net.Dst().String(),
+			CaptureTime:    time.Now(),
+			Size:           len(signedMsg.Payload),
+		}
+		if err := parser.HandleDecodedMessage(signedMsg.Payload, meta); err != nil {
 			log.Printf("[L3-L5] Message handler error: %v", err)
 		}
 	}
@@ -0,0 +1,86 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/proposal"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const maxSeenMetricBlocks = 1024
+
+type BlockMetrics struct {
+	Round         util.Round  `json:"round"`
+	SeqNum        util.SeqNum `json:"seqNum"`
+	BlockID       string      `json:"blockId"`
+	Author        string      `json:"author"`
+	Self          bool        `json:"self,omitempty"`
+	Number        uint64      `json:"number"`
+	Timestamp     uint64      `json:"timestamp"`
+	GasLimit      uint64      `json:"gasLimit"`
+	BaseFeePerGas uint64      `json:"baseFeePerGas"`
+	BaseFee       *uint64     `json:"baseFee,omitempty"`
+	BaseFeeTrend  *uint64     `json:"baseFeeTrend,omitempty"`
+	BaseFeeMoment *uint64     `json:"baseFeeMoment,omitempty"`
+	TxCount       int         `json:"txCount"`
+	TxGasLimitSum uint64      `json:"txGasLimitSum"`
+	PayloadBytes  int         `json:"payloadBytes"`
+	CapturedAt    int64       `json:"capturedAt"`
+}
+
+// BlockMetricsTracker 는 제안마다 수수료 시장과 블록 사용량을 요약한 작은 이벤트를 내보냅니다.
+type BlockMetricsTracker struct {
+	mu   sync.Mutex
+	seen map[util.BlockID]bool
+}
+
+func NewBlockMetricsTracker() *BlockMetricsTracker {
+	return &BlockMetricsTracker{
+		seen: make(map[util.BlockID]bool),
+	}
+}
+
+func (t *BlockMetricsTracker) ObserveProposal(p *proposal.ProposalMessage, meta model.MessageMeta) {
+	t.mu.Lock()
+	if t.seen[p.BlockID] {
+		t.mu.Unlock()
+		return
+	}
+	if len(t.seen) >= maxSeenMetricBlocks {
+		t.seen = make(map[util.BlockID]bool)
+	}
+	t.seen[p.BlockID] = true
+	t.mu.Unlock()
+
+	header := p.Tip.BlockHeader
+	inputs := header.ExecutionInputs
+	metrics := BlockMetrics{
+		Round:         header.BlockRound,
+		SeqNum:        header.SeqNum,
+		BlockID:       p.BlockID.Hex(),
+		Author:        hex.EncodeToString(header.Author),
+		Number:        inputs.Number,
+		Timestamp:     inputs.Timestamp,
+		GasLimit:      inputs.GasLimit,
+		BaseFeePerGas: inputs.BaseFeePerGas,
+		BaseFee:       header.BaseFee,
+		BaseFeeTrend:  header.BaseFeeTrend,
+		BaseFeeMoment: header.BaseFeeMoment,
+		PayloadBytes:  meta.Size,
+		CapturedAt:    meta.CaptureTime.UnixMicro(),
+	}
+	if p.BlockBody != nil {
+		txs := p.BlockBody.ExecutionBody.Transactions
+		metrics.TxCount = len(txs)
+		for _, tx := range txs {
+			if tx != nil {
+				metrics.TxGasLimitSum += tx.Gas()
+			}
+		}
+	}
+	metrics.Self = localNode.IsSelf(metrics.Author)
+	publisher.Publish(util.BLOCK_METRICS_EVENT, metrics)
+}
@@ -0,0 +1,235 @@
+package tracker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/block_sync_request"
+	"monad-flow/model/message/outbound_router/monad/block_sync_response"
+	monad_common "monad-flow/model/message/outbound_router/monad/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	blockSyncRequestTimeout = 30 * time.Second
+	maxPendingBlockSync     = 4096
+
+	// catchUpWindow 동안 catchUpThreshold 개 이상의 요청이 보이면 따라잡기 중으로 판단합니다.
+	catchUpWindow    = 10 * time.Second
+	catchUpThreshold = 20
+)
+
+const (
+	BlockSyncKindHeaders = "headers"
+	BlockSyncKindBody    = "body"
+
+	BlockSyncFound        = "found"
+	BlockSyncNotAvailable = "not_available"
+	BlockSyncTimeout      = "timeout"
+)
+
+type BlockSyncResult struct {
+	Kind        string  `json:"kind"`
+	Requester   string  `json:"requester"`
+	Responder   string  `json:"responder"`
+	LastBlockID string  `json:"lastBlockId,omitempty"`
+	NumBlocks   uint64  `json:"numBlocks,omitempty"`
+	BodyID      string  `json:"bodyId,omitempty"`
+	Outcome     string  `json:"outcome"`
+	Matched     bool    `json:"matched"`
+	Retries     int     `json:"retries"`
+	LatencyMs   float64 `json:"latencyMs"`
+	RequestedAt int64   `json:"requestedAt,omitempty"`
+}
+
+type CatchUpSignal struct {
+	CatchingUp       bool  `json:"catchingUp"`
+	RequestsInWindow int   `json:"requestsInWindow"`
+	WindowMs         int64 `json:"windowMs"`
+	Outstanding      int   `json:"outstanding"`
+}
+
+type pendingBlockSync struct {
+	result BlockSyncResult
+	sentAt time.Time
+}
+
+type BlockSyncTracker struct {
+	mu             sync.Mutex
+	pending        map[string]*pendingBlockSync
+	recentRequests []time.Time
+	catchingUp     bool
+}
+
+func NewBlockSyncTracker() *BlockSyncTracker {
+	return &BlockSyncTracker{
+		pending: make(map[string]*pendingBlockSync),
+	}
+}
+
+func headersKey(requester, responder string, r monad_common.BlockRange) string {
+	return fmt.Sprintf("%s:%s>%s:%s:%d", BlockSyncKindHeaders, requester, responder, r.LastBlockID.Hex(), r.NumBlocks)
+}
+
+func bodyKey(requester, responder string, id util.ConsensusBlockBodyId) string {
+	return fmt.Sprintf("%s:%s>%s:%s", BlockSyncKindBody, requester, responder, id.Hex())
+}
+
+func (t *BlockSyncTracker) ObserveRequest(req *block_sync_request.BlockSyncRequest, meta model.MessageMeta) {
+	result := BlockSyncResult{
+		Requester:   meta.SrcIP,
+		Responder:   meta.DstIP,
+		RequestedAt: meta.CaptureTime.UnixMicro(),
+	}
+
+	var key string
+	switch {
+	case req.IsHeaders:
+		result.Kind = BlockSyncKindHeaders
+		result.LastBlockID = req.Headers.LastBlockID.Hex()
+		result.NumBlocks = uint64(req.Headers.NumBlocks)
+		key = headersKey(meta.SrcIP, meta.DstIP, req.Headers)
+	case req.IsPayload:
+		result.Kind = BlockSyncKindBody
+		result.BodyID = req.Payload.Hex()
+		key = bodyKey(meta.SrcIP, meta.DstIP, req.Payload)
+	default:
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if existing, ok := t.pending[key]; ok {
+		existing.result.Retries++
+	} else if len(t.pending) < maxPendingBlockSync {
+		t.pending[key] = &pendingBlockSync{result: result, sentAt: meta.CaptureTime}
+	}
+
+	t.recentRequests = append(t.recentRequests, meta.CaptureTime)
+	t.updateCatchUpLocked(meta.CaptureTime)
+}
+
+func (t *BlockSyncTracker) ObserveResponse(resp *block_sync_response.BlockSyncResponse, meta model.MessageMeta) {
+	// 응답은 요청의 반대 방향으로 흐릅니다.
+	requester, responder := meta.DstIP, meta.SrcIP
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case resp.HeadersData != nil:
+		data := resp.HeadersData
+		outcome, blockRange := BlockSyncFound, data.FoundRange
+		if data.TypeID == util.NotAvailable {
+			outcome, blockRange = BlockSyncNotAvailable, data.NotAvailRange
+		}
+		key := headersKey(requester, responder, blockRange)
+		result := BlockSyncResult{
+			Kind:        BlockSyncKindHeaders,
+			Requester:   requester,
+			Responder:   responder,
+			LastBlockID: blockRange.LastBlockID.Hex(),
+			NumBlocks:   uint64(blockRange.NumBlocks),
+		}
+		t.resolveLocked(key, result, outcome, meta.CaptureTime)
+
+	case resp.PayloadData != nil:
+		data := resp.PayloadData
+		result := BlockSyncResult{
+			Kind:      BlockSyncKindBody,
+			Requester: requester,
+			Responder: responder,
+		}
+		if data.TypeID == util.NotAvailable {
+			result.BodyID = data.NotAvailPayload.Hex()
+			t.resolveLocked(bodyKey(requester, responder, data.NotAvailPayload), result, BlockSyncNotAvailable, meta.CaptureTime)
+			return
+		}
+		// Found 응답에는 body ID 가 없으므로 body 를 해시해 요청과 짝지우고,
+		// 그래도 없으면 같은 피어 쌍의 가장 오래된 body 요청과 짝지웁니다.
+		key := ""
+		if data.FoundBody != nil {
+			bodyID := data.FoundBody.BodyID()
+			result.BodyID = bodyID.Hex()
+			key = bodyKey(requester, responder, bodyID)
+		}
+		if _, ok := t.pending[key]; !ok {
+			key = t.oldestBodyKeyLocked(requester, responder)
+		}
+		t.resolveLocked(key, result, BlockSyncFound, meta.CaptureTime)
+	}
+}
+
+func (t *BlockSyncTracker) oldestBodyKeyLocked(requester, responder string) string {
+	var oldestKey string
+	var oldest time.Time
+	for key, p := range t.pending {
+		if p.result.Kind != BlockSyncKindBody || p.result.Requester != requester || p.result.Responder != responder {
+			continue
+		}
+		if oldestKey == "" || p.sentAt.Before(oldest) {
+			oldestKey, oldest = key, p.sentAt
+		}
+	}
+	return oldestKey
+}
+
+func (t *BlockSyncTracker) resolveLocked(key string, result BlockSyncResult, outcome string, at time.Time) {
+	if p, ok := t.pending[key]; ok {
+		delete(t.pending, key)
+		result = p.result
+		result.Matched = true
+		result.LatencyMs = float64(at.Sub(p.sentAt).Microseconds()) / 1000.0
+	}
+	result.Outcome = outcome
+	publisher.Publish(util.BLOCK_SYNC_EVENT, result)
+}
+
+func (t *BlockSyncTracker) updateCatchUpLocked(now time.Time) {
+	cutoff := now.Add(-catchUpWindow)
+	i := 0
+	for i < len(t.recentRequests) && t.recentRequests[i].Before(cutoff) {
+		i++
+	}
+	t.recentRequests = t.recentRequests[i:]
+
+	count := len(t.recentRequests)
+	switch {
+	case !t.catchingUp && count >= catchUpThreshold:
+		t.catchingUp = true
+	case t.catchingUp && count < catchUpThreshold/2:
+		t.catchingUp = false
+	default:
+		return
+	}
+
+	publisher.Publish(util.BLOCK_SYNC_CATCHUP_EVENT, CatchUpSignal{
+		CatchingUp:       t.catchingUp,
+		RequestsInWindow: count,
+		WindowMs:         catchUpWindow.Milliseconds(),
+		Outstanding:      len(t.pending),
+	})
+}
+
+// Sweep 은 응답 없이 오래된 요청을 timeout 으로 정리하고 따라잡기 상태를 갱신합니다.
+func (t *BlockSyncTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, p := range t.pending {
+		if now.Sub(p.sentAt) < blockSyncRequestTimeout {
+			continue
+		}
+		delete(t.pending, key)
+		result := p.result
+		result.Outcome = BlockSyncTimeout
+		result.LatencyMs = float64(now.Sub(p.sentAt).Microseconds()) / 1000.0
+		publisher.Publish(util.BLOCK_SYNC_EVENT, result)
+	}
+
+	t.updateCatchUpLocked(now)
+}
@@ -0,0 +1,211 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const maxBlockRounds = 256
+
+type OrphanBlock struct {
+	BlockID    string      `json:"blockId"`
+	ParentID   string      `json:"parentId"`
+	Round      util.Round  `json:"round"`
+	Epoch      util.Epoch  `json:"epoch"`
+	SeqNum     util.SeqNum `json:"seqNum"`
+	Author     string      `json:"author"`
+	ProposedAt int64       `json:"proposedAt"`
+	// 같은 높이에서 커밋된 블록 (알 수 없으면 빈 값)
+	CanonicalID string `json:"canonicalId,omitempty"`
+}
+
+// BlockCommit 은 블록이 커밋 규칙을 만족한 시점과 그때까지의 지연입니다.
+type BlockCommit struct {
+	BlockID            string      `json:"blockId"`
+	Round              util.Round  `json:"round"`
+	Epoch              util.Epoch  `json:"epoch"`
+	SeqNum             util.SeqNum `json:"seqNum"`
+	Author             string      `json:"author"`
+	Self               bool        `json:"self,omitempty"`
+	ProposedAt         int64       `json:"proposedAt"`
+	QCAt               int64       `json:"qcAt,omitempty"`
+	CommittedAt        int64       `json:"committedAt"`
+	ProposalToQCMs     float64     `json:"proposalToQcMs,omitempty"`
+	ProposalToCommitMs float64     `json:"proposalToCommitMs"`
+}
+
+type blockNode struct {
+	id          util.BlockID
+	parent      util.BlockID
+	parentRound util.Round
+	round       util.Round
+	epoch       util.Epoch
+	seqNum      util.SeqNum
+	author      string
+	proposedAt  time.Time
+	qcAt        time.Time
+	committed   bool
+	orphaned    bool
+}
+
+// BlockTree 는 제안 헤더의 QC 가 가리키는 부모 블록으로 블록 트리를 구성합니다.
+// 커밋된 블록은 높이(SeqNum)별로 기억해 두고, 그와 충돌하는 블록을 고아로 봅니다.
+type BlockTree struct {
+	mu        sync.Mutex
+	blocks    map[util.BlockID]*blockNode
+	committed map[util.SeqNum]util.BlockID
+	maxRound  util.Round
+}
+
+func NewBlockTree() *BlockTree {
+	return &BlockTree{
+		blocks:    make(map[util.BlockID]*blockNode),
+		committed: make(map[util.SeqNum]util.BlockID),
+	}
+}
+
+func (t *BlockTree) ObserveProposal(header *common.ConsensusBlockHeader, blockID util.BlockID, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.blocks[blockID]; ok {
+		return
+	}
+	node := &blockNode{
+		id:          blockID,
+		parent:      header.QC.Info.ID,
+		parentRound: header.QC.Info.Round,
+		round:       header.BlockRound,
+		epoch:       header.Epoch,
+		seqNum:      header.SeqNum,
+		author:      hex.EncodeToString(header.Author),
+		proposedAt:  meta.CaptureTime,
+	}
+	t.blocks[blockID] = node
+
+	t.observeQCLocked(header.QC.Info.ID, meta.CaptureTime)
+	// 이미 커밋된 높이의 블록이 늦게 도착했을 수도 있습니다.
+	t.reportOrphanLocked(node)
+
+	if node.round > t.maxRound {
+		t.maxRound = node.round
+		t.pruneLocked()
+	}
+}
+
+// ObserveQC 는 제안 외의 메시지 (AdvanceRound, Timeout) 로 전달된 QC 를 반영합니다.
+func (t *BlockTree) ObserveQC(qc *common.QuorumCertificate, now time.Time) {
+	if qc == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.observeQCLocked(qc.Info.ID, now)
+}
+
+// observeQCLocked 는 monad-bft 의 2-chain 커밋 규칙을 적용합니다.
+// QC 를 받은 블록 B' 의 부모 B 가 바로 앞 라운드 (B'.round == B.round+1) 이면 B 와 그 조상이 커밋됩니다.
+func (t *BlockTree) observeQCLocked(id util.BlockID, now time.Time) {
+	certified, ok := t.blocks[id]
+	if !ok {
+		return
+	}
+	if certified.qcAt.IsZero() {
+		certified.qcAt = now
+	}
+	if certified.parentRound+1 != certified.round {
+		return
+	}
+
+	var newlyCommitted []*blockNode
+	for node, ok := t.blocks[certified.parent]; ok && !node.committed; node, ok = t.blocks[node.parent] {
+		node.committed = true
+		newlyCommitted = append(newlyCommitted, node)
+	}
+	for i := len(newlyCommitted) - 1; i >= 0; i-- {
+		t.committed[newlyCommitted[i].seqNum] = newlyCommitted[i].id
+		commit := newlyCommitted[i].commit(now)
+		commit.Self = localNode.IsSelf(commit.Author)
+		publisher.Publish(util.BLOCK_COMMIT_EVENT, commit)
+	}
+	if len(newlyCommitted) > 0 {
+		for _, node := range t.blocks {
+			t.reportOrphanLocked(node)
+		}
+	}
+}
+
+// reportOrphanLocked 는 같은 높이에 다른 블록이 커밋됐거나, 바로 아래 높이에 커밋된 블록이 부모가 아닌
+// 블록을 고아로 보고합니다. 어느 쪽도 아직 알 수 없으면 판단을 미룹니다.
+// 자식 제안을 못 본 것만으로는 고아로 보지 않습니다.
+func (t *BlockTree) reportOrphanLocked(node *blockNode) {
+	if node.committed || node.orphaned {
+		return
+	}
+	canonical, ok := t.committed[node.seqNum]
+	conflicting := ok && canonical != node.id
+	if !conflicting && node.seqNum > 0 {
+		parent, parentOK := t.committed[node.seqNum-1]
+		conflicting = parentOK && parent != node.parent
+	}
+	if !conflicting {
+		return
+	}
+
+	node.orphaned = true
+	orphan := OrphanBlock{
+		BlockID:    node.id.Hex(),
+		ParentID:   node.parent.Hex(),
+		Round:      node.round,
+		Epoch:      node.epoch,
+		SeqNum:     node.seqNum,
+		Author:     node.author,
+		ProposedAt: node.proposedAt.UnixMicro(),
+	}
+	if ok {
+		orphan.CanonicalID = canonical.Hex()
+	}
+	publisher.Publish(util.ORPHAN_BLOCK_EVENT, orphan)
+}
+
+func (n *blockNode) commit(now time.Time) BlockCommit {
+	commit := BlockCommit{
+		BlockID:            n.id.Hex(),
+		Round:              n.round,
+		Epoch:              n.epoch,
+		SeqNum:             n.seqNum,
+		Author:             n.author,
+		ProposedAt:         n.proposedAt.UnixMicro(),
+		CommittedAt:        now.UnixMicro(),
+		ProposalToCommitMs: float64(now.Sub(n.proposedAt).Microseconds()) / 1000.0,
+	}
+	if !n.qcAt.IsZero() {
+		commit.QCAt = n.qcAt.UnixMicro()
+		commit.ProposalToQCMs = float64(n.qcAt.Sub(n.proposedAt).Microseconds()) / 1000.0
+	}
+	return commit
+}
+
+// pruneLocked 는 maxBlockRounds 보다 오래된 블록을 트리에서 지웁니다.
+func (t *BlockTree) pruneLocked() {
+	if t.maxRound <= maxBlockRounds {
+		return
+	}
+	dropCutoff := t.maxRound - maxBlockRounds
+
+	for id, node := range t.blocks {
+		if node.round >= dropCutoff {
+			continue
+		}
+		delete(t.blocks, id)
+		if t.committed[node.seqNum] == id {
+			delete(t.committed, node.seqNum)
+		}
+	}
+}
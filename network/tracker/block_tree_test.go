@@ -0,0 +1,152 @@
+package tracker
+
+import (
+	"testing"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/vote"
+	"monad-flow/util"
+)
+
+type testBlock struct {
+	id, parent  byte
+	parentRound util.Round
+	round       util.Round
+	seqNum      util.SeqNum
+}
+
+func blockID(b byte) util.BlockID {
+	return util.BlockID{b}
+}
+
+func proposeBlock(tree *BlockTree, b testBlock, at time.Time) {
+	header := &common.ConsensusBlockHeader{
+		BlockRound: b.round,
+		Epoch:      1,
+		SeqNum:     b.seqNum,
+		Author:     make(util.NodeID, 33),
+		QC: common.QuorumCertificate{
+			Info: vote.Vote{ID: blockID(b.parent), Round: b.parentRound, Epoch: 1},
+		},
+	}
+	tree.ObserveProposal(header, blockID(b.id), model.MessageMeta{CaptureTime: at})
+}
+
+func TestBlockTreeOrphans(t *testing.T) {
+	// 1 <- 2 <- 3 <- 4 <- 5 는 연속 라운드 체인이고, 나머지는 그와 갈라지는 블록입니다.
+	chain := []testBlock{
+		{id: 1, parent: 0, parentRound: 0, round: 1, seqNum: 1},
+		{id: 2, parent: 1, parentRound: 1, round: 2, seqNum: 2},
+		{id: 3, parent: 2, parentRound: 2, round: 3, seqNum: 3},
+		{id: 4, parent: 3, parentRound: 3, round: 4, seqNum: 4},
+		{id: 5, parent: 4, parentRound: 4, round: 5, seqNum: 5},
+	}
+	fork := testBlock{id: 0x30, parent: 2, parentRound: 2, round: 3, seqNum: 3}
+	forkChild := testBlock{id: 0x40, parent: 0x30, parentRound: 3, round: 4, seqNum: 4}
+
+	tests := []struct {
+		name    string
+		blocks  []testBlock
+		orphans []byte
+	}{
+		{name: "linear chain has no orphans", blocks: chain},
+		{name: "competing block at a committed height", blocks: append([]testBlock{fork}, chain...), orphans: []byte{0x30}},
+		{name: "late block at a committed height", blocks: append(append([]testBlock{}, chain...), fork), orphans: []byte{0x30}},
+		{name: "child of an orphan", blocks: append([]testBlock{fork, forkChild}, chain...), orphans: []byte{0x30, 0x40}},
+		{name: "uncommitted fork is not an orphan yet", blocks: append(append([]testBlock{}, chain[:3]...), fork)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewBlockTree()
+			now := time.Unix(0, 0)
+			for _, b := range tt.blocks {
+				now = now.Add(time.Second)
+				proposeBlock(tree, b, now)
+			}
+
+			want := make(map[util.BlockID]bool)
+			for _, id := range tt.orphans {
+				want[blockID(id)] = true
+			}
+			for id, node := range tree.blocks {
+				if node.orphaned != want[id] {
+					t.Errorf("block %x orphaned = %v, want %v", id[0], node.orphaned, want[id])
+				}
+			}
+		})
+	}
+}
+
+func TestBlockTreeTwoChainCommit(t *testing.T) {
+	tests := []struct {
+		name      string
+		blocks    []testBlock
+		qc        *testBlock
+		committed []byte
+	}{
+		{
+			name: "consecutive rounds commit the grandparent",
+			blocks: []testBlock{
+				{id: 1, parent: 0, parentRound: 0, round: 1, seqNum: 1},
+				{id: 2, parent: 1, parentRound: 1, round: 2, seqNum: 2},
+				{id: 3, parent: 2, parentRound: 2, round: 3, seqNum: 3},
+			},
+			committed: []byte{1},
+		},
+		{
+			name: "round gap blocks the commit",
+			blocks: []testBlock{
+				{id: 1, parent: 0, parentRound: 0, round: 1, seqNum: 1},
+				{id: 2, parent: 1, parentRound: 1, round: 3, seqNum: 2},
+				{id: 3, parent: 2, parentRound: 3, round: 4, seqNum: 3},
+			},
+		},
+		{
+			name: "commit after a gap includes skipped ancestors",
+			blocks: []testBlock{
+				{id: 1, parent: 0, parentRound: 0, round: 1, seqNum: 1},
+				{id: 2, parent: 1, parentRound: 1, round: 3, seqNum: 2},
+				{id: 3, parent: 2, parentRound: 3, round: 4, seqNum: 3},
+				{id: 4, parent: 3, parentRound: 4, round: 5, seqNum: 4},
+			},
+			committed: []byte{1, 2},
+		},
+		{
+			name: "QC outside a proposal",
+			blocks: []testBlock{
+				{id: 1, parent: 0, parentRound: 0, round: 1, seqNum: 1},
+				{id: 2, parent: 1, parentRound: 1, round: 2, seqNum: 2},
+			},
+			qc:        &testBlock{id: 2, round: 2},
+			committed: []byte{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree := NewBlockTree()
+			now := time.Unix(0, 0)
+			for _, b := range tt.blocks {
+				now = now.Add(time.Second)
+				proposeBlock(tree, b, now)
+			}
+			if tt.qc != nil {
+				tree.ObserveQC(&common.QuorumCertificate{Info: vote.Vote{ID: blockID(tt.qc.id), Round: tt.qc.round}}, now)
+			}
+
+			want := make(map[util.BlockID]bool)
+			for _, id := range tt.committed {
+				want[blockID(id)] = true
+			}
+			for id, node := range tree.blocks {
+				if node.committed != want[id] {
+					t.Errorf("block %x committed = %v, want %v", id[0], node.committed, want[id])
+				}
+				if want[id] && tree.committed[node.seqNum] != id {
+					t.Errorf("block %x is not recorded at seqNum %d", id[0], node.seqNum)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package tracker
+
+import (
+	"encoding/hex"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/advanced_round"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/no_endorsement"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/proposal"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/round_recovery"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/timeout"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/vote"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+type BlockBodyMismatch struct {
+	Round          util.Round  `json:"round"`
+	Epoch          util.Epoch  `json:"epoch"`
+	SeqNum         util.SeqNum `json:"seqNum"`
+	Author         string      `json:"author"`
+	BlockID        string      `json:"blockId"`
+	HeaderBodyID   string      `json:"headerBodyId"`
+	ComputedBodyID string      `json:"computedBodyId"`
+}
+
+func observeConsensusMessage(msg *consensus.ConsensusMessage, meta model.MessageMeta) {
+	protoMsg, ok := msg.Payload.(*protocol.ProtocolMessage)
+	if !ok {
+		return
+	}
+
+	switch payload := protoMsg.Payload.(type) {
+	case *proposal.ProposalMessage:
+		observeProposal(payload, meta)
+	case *vote.VoteMessage:
+		rounds.ObserveVote(payload.Vote.Round, payload.Vote.Epoch, meta)
+		equivocs.ObserveVote(payload, meta)
+		inferred.ObserveSigner(payload.Vote.Epoch, payload.Vote.Round, meta.Author, ParticipationVote, meta)
+	case *timeout.TimeoutMessage:
+		if payload.TMInfo != nil {
+			rounds.ObserveTimeout(payload.TMInfo.Round, payload.TMInfo.Epoch, payload.LastRoundCertificate, meta)
+			inferred.ObserveSigner(payload.TMInfo.Epoch, payload.TMInfo.Round, meta.Author, ParticipationTimeout, meta)
+		}
+		tcDiag.ObserveTimeout(payload, meta)
+		stalls.ObserveTimeout(payload, meta)
+		observeRoundCertificate(payload.LastRoundCertificate, meta)
+	case *advanced_round.AdvanceRoundMessage:
+		rounds.ObserveAdvanceRound(payload.LastRoundCertificate, meta)
+		observeRoundCertificate(payload.LastRoundCertificate, meta)
+	case *round_recovery.RoundRecoveryMessage:
+		rounds.ObserveRoundRecovery(payload.Round, payload.Epoch, payload.TC, meta)
+		tcDiag.ObserveTC(payload.TC, meta)
+		stalls.ObserveTC(payload.TC, meta.CaptureTime)
+	case *no_endorsement.NoEndorsementMessage:
+		if payload.Msg != nil {
+			rounds.ObserveNoEndorsement(payload.Msg.Round, payload.Msg.Epoch, meta)
+		}
+	}
+}
+
+func observeRoundCertificate(cert *common.RoundCertificateWrapper, meta model.MessageMeta) {
+	if cert == nil {
+		return
+	}
+	switch c := cert.Certificate.(type) {
+	case *common.RoundCertificateQC:
+		blockTree.ObserveQC(c.QC, meta.CaptureTime)
+		stalls.ObserveQC(c.QC, meta.CaptureTime)
+	case *common.RoundCertificateTC:
+		tcDiag.ObserveTC(c.TC, meta)
+		stalls.ObserveTC(c.TC, meta.CaptureTime)
+	}
+}
+
+func observeProposal(p *proposal.ProposalMessage, meta model.MessageMeta) {
+	if p.Tip == nil || p.Tip.BlockHeader == nil {
+		return
+	}
+	header := p.Tip.BlockHeader
+
+	rounds.ObserveProposal(p.ProposalRound, p.ProposalEpoch, header, p.BlockID, p.LastRoundTC, meta)
+	leaders.ObserveProposal(p.ProposalRound, header, p.BlockID, meta)
+	tcDiag.ObserveTC(p.LastRoundTC, meta)
+	stalls.ObserveTC(p.LastRoundTC, meta.CaptureTime)
+	execDelay.ObserveProposal(header, meta)
+	execFork.ObserveProposal(header, p.BlockID, meta)
+	equivocs.ObserveProposal(p, meta)
+	if header.BlockRound == p.ProposalRound {
+		inferred.ObserveSigner(header.Epoch, header.BlockRound, hex.EncodeToString(header.Author), ParticipationProposal, meta)
+		// 재제안의 헤더 타임스탬프는 원래 제안 시각이라 전파 지연으로 쓸 수 없습니다.
+		propagate.ObserveProposal(header, p.BlockID, meta)
+		localNode.ObserveProposal(header, meta)
+	}
+	blockTree.ObserveProposal(header, p.BlockID, meta)
+	blockStats.ObserveProposal(p, meta)
+	localNode.ObserveQC(&header.QC)
+	stalls.ObserveProposal(p, meta)
+
+	if p.BlockBody != nil && !p.BlockBodyValid {
+		publisher.Publish(util.BLOCK_BODY_MISMATCH_EVENT, BlockBodyMismatch{
+			Round:          p.ProposalRound,
+			Epoch:          p.ProposalEpoch,
+			SeqNum:         header.SeqNum,
+			Author:         hex.EncodeToString(header.Author),
+			BlockID:        p.BlockID.Hex(),
+			HeaderBodyID:   header.BlockBodyID.Hex(),
+			ComputedBodyID: p.BlockBody.BodyID().Hex(),
+		})
+	}
+}
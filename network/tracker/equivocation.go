@@ -0,0 +1,157 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/proposal"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/vote"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+// 현재 라운드에서 이만큼 이전의 라운드 인덱스는 버립니다.
+const equivocationRoundWindow = 64
+
+const (
+	EquivocationProposal = "proposal"
+	EquivocationVote     = "vote"
+)
+
+// SignedMessageEvidence 는 충돌하는 두 메시지 중 하나를 검증 가능한 형태로 담습니다.
+type SignedMessageEvidence struct {
+	BlockID        string      `json:"blockId"`
+	SeqNum         util.SeqNum `json:"seqNum,omitempty"`
+	HeaderRLP      string      `json:"headerRlp,omitempty"`
+	Signature      string      `json:"signature"`
+	SrcIP          string      `json:"srcIp"`
+	AppMessageHash string      `json:"appMessageHash"`
+	CapturedAt     int64       `json:"capturedAt"`
+}
+
+type Equivocation struct {
+	Kind   string                `json:"kind"`
+	Round  util.Round            `json:"round"`
+	Epoch  util.Epoch            `json:"epoch"`
+	Signer string                `json:"signer"`
+	First  SignedMessageEvidence `json:"first"`
+	Second SignedMessageEvidence `json:"second"`
+}
+
+type signerRoundKey struct {
+	signer string
+	round  util.Round
+}
+
+// EquivocationDetector 는 라운드별로 작성자의 제안과 서명자의 투표를 기억해 충돌을 찾습니다.
+type EquivocationDetector struct {
+	mu        sync.Mutex
+	proposals map[signerRoundKey]SignedMessageEvidence
+	votes     map[signerRoundKey]SignedMessageEvidence
+	reported  map[signerRoundKey]bool
+	maxRound  util.Round
+}
+
+func NewEquivocationDetector() *EquivocationDetector {
+	return &EquivocationDetector{
+		proposals: make(map[signerRoundKey]SignedMessageEvidence),
+		votes:     make(map[signerRoundKey]SignedMessageEvidence),
+		reported:  make(map[signerRoundKey]bool),
+	}
+}
+
+func (d *EquivocationDetector) ObserveProposal(p *proposal.ProposalMessage, meta model.MessageMeta) {
+	header := p.Tip.BlockHeader
+	// 재제안은 원래 작성자의 헤더를 그대로 싣고 오므로 새 제안만 비교합니다.
+	if header.BlockRound != p.ProposalRound {
+		return
+	}
+
+	evidence := SignedMessageEvidence{
+		BlockID:        p.BlockID.Hex(),
+		SeqNum:         header.SeqNum,
+		HeaderRLP:      hex.EncodeToString(header.Raw()),
+		Signature:      hex.EncodeToString(p.Tip.Signature),
+		SrcIP:          meta.SrcIP,
+		AppMessageHash: meta.AppMessageHash,
+		CapturedAt:     meta.CaptureTime.UnixMicro(),
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.observeLocked(d.proposals, EquivocationProposal, hex.EncodeToString(header.Author), header.BlockRound, header.Epoch, evidence)
+}
+
+func (d *EquivocationDetector) ObserveVote(v *vote.VoteMessage, meta model.MessageMeta) {
+	// 서명자를 복구할 수 없는 TCP 경로의 투표는 비교할 수 없습니다.
+	if meta.Author == "" {
+		return
+	}
+
+	evidence := SignedMessageEvidence{
+		BlockID:        v.Vote.ID.Hex(),
+		Signature:      hex.EncodeToString(v.Sig),
+		SrcIP:          meta.SrcIP,
+		AppMessageHash: meta.AppMessageHash,
+		CapturedAt:     meta.CaptureTime.UnixMicro(),
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.observeLocked(d.votes, EquivocationVote, meta.Author, v.Vote.Round, v.Vote.Epoch, evidence)
+}
+
+func (d *EquivocationDetector) observeLocked(index map[signerRoundKey]SignedMessageEvidence, kind string, signer string, round util.Round, epoch util.Epoch, evidence SignedMessageEvidence) {
+	if round > d.maxRound {
+		d.maxRound = round
+		d.pruneLocked()
+	}
+	if d.maxRound >= equivocationRoundWindow && round < d.maxRound-equivocationRoundWindow {
+		return
+	}
+
+	key := signerRoundKey{signer: signer, round: round}
+	first, ok := index[key]
+	if !ok {
+		index[key] = evidence
+		return
+	}
+	if first.BlockID == evidence.BlockID {
+		return
+	}
+
+	reportKey := signerRoundKey{signer: kind + "/" + signer, round: round}
+	if d.reported[reportKey] {
+		return
+	}
+	d.reported[reportKey] = true
+
+	publisher.Publish(util.EQUIVOCATION_EVENT, Equivocation{
+		Kind:   kind,
+		Round:  round,
+		Epoch:  epoch,
+		Signer: signer,
+		First:  first,
+		Second: evidence,
+	})
+}
+
+func (d *EquivocationDetector) pruneLocked() {
+	if d.maxRound < equivocationRoundWindow {
+		return
+	}
+	cutoff := d.maxRound - equivocationRoundWindow
+	for _, index := range []map[signerRoundKey]SignedMessageEvidence{d.proposals, d.votes} {
+		for key := range index {
+			if key.round < cutoff {
+				delete(index, key)
+			}
+		}
+	}
+	for key := range d.reported {
+		if key.round < cutoff {
+			delete(d.reported, key)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package tracker
+
+import (
+	"testing"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/proposal"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/vote"
+	"monad-flow/util"
+)
+
+func testProposal(author byte, proposalRound, blockRound util.Round, id byte) *proposal.ProposalMessage {
+	nodeID := make(util.NodeID, 33)
+	nodeID[0] = author
+	return &proposal.ProposalMessage{
+		ProposalRound: proposalRound,
+		ProposalEpoch: 1,
+		Tip: &common.ConsensusTip{BlockHeader: &common.ConsensusBlockHeader{
+			BlockRound: blockRound,
+			Epoch:      1,
+			Author:     nodeID,
+		}},
+		BlockID: blockID(id),
+	}
+}
+
+func TestEquivocationProposals(t *testing.T) {
+	tests := []struct {
+		name      string
+		proposals []*proposal.ProposalMessage
+		want      bool
+	}{
+		{name: "same block twice", proposals: []*proposal.ProposalMessage{testProposal(1, 5, 5, 1), testProposal(1, 5, 5, 1)}},
+		{name: "two blocks in one round", proposals: []*proposal.ProposalMessage{testProposal(1, 5, 5, 1), testProposal(1, 5, 5, 2)}, want: true},
+		{name: "different rounds", proposals: []*proposal.ProposalMessage{testProposal(1, 5, 5, 1), testProposal(1, 6, 6, 2)}},
+		{name: "different authors", proposals: []*proposal.ProposalMessage{testProposal(1, 5, 5, 1), testProposal(2, 5, 5, 2)}},
+		// 재제안은 원래 라운드의 헤더를 싣고 오므로 비교하지 않습니다.
+		{name: "reproposal", proposals: []*proposal.ProposalMessage{testProposal(1, 5, 5, 1), testProposal(1, 6, 5, 2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewEquivocationDetector()
+			for _, p := range tt.proposals {
+				d.ObserveProposal(p, model.MessageMeta{})
+			}
+			if got := len(d.reported) > 0; got != tt.want {
+				t.Fatalf("reported = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEquivocationVotes(t *testing.T) {
+	type observed struct {
+		signer string
+		round  util.Round
+		id     byte
+	}
+	tests := []struct {
+		name  string
+		votes []observed
+		want  bool
+	}{
+		{name: "same vote twice", votes: []observed{{"a", 5, 1}, {"a", 5, 1}}},
+		{name: "two blocks in one round", votes: []observed{{"a", 5, 1}, {"a", 5, 2}}, want: true},
+		{name: "different signers", votes: []observed{{"a", 5, 1}, {"b", 5, 2}}},
+		// 서명자를 모르는 투표는 비교하지 않습니다.
+		{name: "unknown signer", votes: []observed{{"", 5, 1}, {"", 5, 2}}},
+		{name: "outside the round window", votes: []observed{{"a", 5, 1}, {"b", 5 + equivocationRoundWindow + 1, 1}, {"a", 5, 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewEquivocationDetector()
+			for _, v := range tt.votes {
+				msg := &vote.VoteMessage{Vote: vote.Vote{ID: blockID(v.id), Round: v.round, Epoch: 1}}
+				d.ObserveVote(msg, model.MessageMeta{Author: v.signer})
+			}
+			if got := len(d.reported) > 0; got != tt.want {
+				t.Fatalf("reported = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
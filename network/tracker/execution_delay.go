@@ -0,0 +1,102 @@
+package tracker
+
+import (
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const maxTrackedSeqNums = 4096
+
+type ExecutionDelay struct {
+	Round           util.Round  `json:"round"`
+	SeqNum          util.SeqNum `json:"seqNum"`
+	ExecutionNumber uint64      `json:"executionNumber"`
+	Gap             int64       `json:"gap"` // SeqNum - ExecutionNumber
+	// 실행 결과가 실린 블록의 제안을 처음 본 시각부터 현재 제안까지 (관측 못 했으면 0)
+	WallDelayMs float64 `json:"wallDelayMs,omitempty"`
+	// 두 블록 헤더 타임스탬프의 차이
+	TimestampDelayMs float64 `json:"timestampDelayMs"`
+}
+
+// ExecutionDelayTracker 는 제안에 실린 지연 실행 결과로 monad-execution 이 합의보다 얼마나 뒤처졌는지 계산합니다.
+type ExecutionDelayTracker struct {
+	mu         sync.Mutex
+	firstSeen  map[util.SeqNum]time.Time
+	lastSeqNum util.SeqNum
+}
+
+func NewExecutionDelayTracker() *ExecutionDelayTracker {
+	return &ExecutionDelayTracker{
+		firstSeen: make(map[util.SeqNum]time.Time),
+	}
+}
+
+func (t *ExecutionDelayTracker) ObserveProposal(header *common.ConsensusBlockHeader, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.firstSeen[header.SeqNum]; !ok {
+		if len(t.firstSeen) >= maxTrackedSeqNums {
+			t.pruneLocked()
+		}
+		t.firstSeen[header.SeqNum] = meta.CaptureTime
+	}
+
+	// 재제안이나 같은 높이의 중복 수신은 한 번만 보고합니다.
+	if header.SeqNum <= t.lastSeqNum {
+		return
+	}
+	t.lastSeqNum = header.SeqNum
+
+	newest := newestExecutionResult(header.DelayedExecutionResults)
+	if newest == nil {
+		return
+	}
+	execNumber := newest.Number.Uint64()
+
+	delay := ExecutionDelay{
+		Round:           header.BlockRound,
+		SeqNum:          header.SeqNum,
+		ExecutionNumber: execNumber,
+		Gap:             int64(header.SeqNum) - int64(execNumber),
+	}
+	if seen, ok := t.firstSeen[util.SeqNum(execNumber)]; ok {
+		delay.WallDelayMs = float64(meta.CaptureTime.Sub(seen).Microseconds()) / 1000.0
+	}
+	proposalNs := header.TimestampNS.Uint64()
+	execNs := newest.Time * uint64(time.Second)
+	delay.TimestampDelayMs = (float64(proposalNs) - float64(execNs)) / 1e6
+
+	publisher.Publish(util.EXECUTION_DELAY_EVENT, delay)
+}
+
+func newestExecutionResult(results []util.FinalizedHeader) *util.FinalizedHeader {
+	var newest *util.FinalizedHeader
+	for i := range results {
+		if results[i].Number == nil {
+			continue
+		}
+		if newest == nil || results[i].Number.Cmp(newest.Number) > 0 {
+			newest = &results[i]
+		}
+	}
+	return newest
+}
+
+// pruneLocked 는 가장 최근 높이 기준으로 오래된 절반을 버립니다.
+func (t *ExecutionDelayTracker) pruneLocked() {
+	cutoff := t.lastSeqNum - maxTrackedSeqNums/2
+	if t.lastSeqNum < maxTrackedSeqNums/2 {
+		cutoff = 0
+	}
+	for seqNum := range t.firstSeen {
+		if seqNum <= cutoff {
+			delete(t.firstSeen, seqNum)
+		}
+	}
+}
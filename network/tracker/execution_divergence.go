@@ -0,0 +1,119 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const maxIndexedExecutionBlocks = 4096
+
+type ExecutionResultReport struct {
+	HeaderHash   string      `json:"headerHash"`
+	StateRoot    string      `json:"stateRoot"`
+	ReceiptsRoot string      `json:"receiptsRoot"`
+	GasUsed      uint64      `json:"gasUsed"`
+	Round        util.Round  `json:"round"`
+	SeqNum       util.SeqNum `json:"seqNum"`
+	BlockID      string      `json:"blockId"`
+	Author       string      `json:"author"`
+	SrcIP        string      `json:"srcIp"`
+	CapturedAt   int64       `json:"capturedAt"`
+}
+
+type ExecutionDivergence struct {
+	Number uint64                `json:"number"`
+	First  ExecutionResultReport `json:"first"`
+	Second ExecutionResultReport `json:"second"`
+}
+
+// ExecutionDivergenceDetector 는 제안에 실린 지연 실행 결과를 블록 번호별로 모아,
+// 같은 블록에 대해 서로 다른 헤더가 보고되면 (실행 포크) 알립니다.
+type ExecutionDivergenceDetector struct {
+	mu        sync.Mutex
+	results   map[uint64]ExecutionResultReport
+	reported  map[uint64]bool
+	maxNumber uint64
+}
+
+func NewExecutionDivergenceDetector() *ExecutionDivergenceDetector {
+	return &ExecutionDivergenceDetector{
+		results:  make(map[uint64]ExecutionResultReport),
+		reported: make(map[uint64]bool),
+	}
+}
+
+func (d *ExecutionDivergenceDetector) ObserveProposal(header *common.ConsensusBlockHeader, blockID util.BlockID, meta model.MessageMeta) {
+	if len(header.DelayedExecutionResults) == 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for i := range header.DelayedExecutionResults {
+		result := (*types.Header)(&header.DelayedExecutionResults[i])
+		if result.Number == nil {
+			continue
+		}
+		number := result.Number.Uint64()
+		if d.maxNumber >= maxIndexedExecutionBlocks && number < d.maxNumber-maxIndexedExecutionBlocks {
+			continue
+		}
+
+		report := ExecutionResultReport{
+			HeaderHash:   result.Hash().Hex(),
+			StateRoot:    result.Root.Hex(),
+			ReceiptsRoot: result.ReceiptHash.Hex(),
+			GasUsed:      result.GasUsed,
+			Round:        header.BlockRound,
+			SeqNum:       header.SeqNum,
+			BlockID:      blockID.Hex(),
+			Author:       hex.EncodeToString(header.Author),
+			SrcIP:        meta.SrcIP,
+			CapturedAt:   meta.CaptureTime.UnixMicro(),
+		}
+
+		first, ok := d.results[number]
+		if !ok {
+			d.results[number] = report
+			if number > d.maxNumber {
+				d.maxNumber = number
+				d.pruneLocked()
+			}
+			continue
+		}
+		if first.HeaderHash == report.HeaderHash || d.reported[number] {
+			continue
+		}
+		d.reported[number] = true
+		publisher.Publish(util.EXECUTION_DIVERGENCE_EVENT, ExecutionDivergence{
+			Number: number,
+			First:  first,
+			Second: report,
+		})
+	}
+}
+
+func (d *ExecutionDivergenceDetector) pruneLocked() {
+	if len(d.results) <= maxIndexedExecutionBlocks {
+		return
+	}
+	cutoff := d.maxNumber - maxIndexedExecutionBlocks
+	for number := range d.results {
+		if number < cutoff {
+			delete(d.results, number)
+		}
+	}
+	for number := range d.reported {
+		if number < cutoff {
+			delete(d.reported, number)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/fullnode_group"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const maxFullNodeGroups = 256
+
+type FullNodeGroupMember struct {
+	NodeID string           `json:"nodeId"`
+	IP     string           `json:"ip,omitempty"`
+	Port   uint16           `json:"port,omitempty"`
+	Ports  map[uint8]uint16 `json:"ports,omitempty"`
+	Seq    uint64           `json:"seq"`
+}
+
+type FullNodeGroup struct {
+	ValidatorID  string                `json:"validatorId"`
+	MaxGroupSize uint64                `json:"maxGroupSize"`
+	StartRound   util.Round            `json:"startRound"`
+	EndRound     util.Round            `json:"endRound"`
+	InvitedIPs   []string              `json:"invitedIps"`
+	Accepted     []string              `json:"accepted"`
+	Rejected     []string              `json:"rejected"`
+	Confirmed    bool                  `json:"confirmed"`
+	Peers        []FullNodeGroupMember `json:"peers"`
+	PreparedAt   int64                 `json:"preparedAt,omitempty"`
+	ConfirmedAt  int64                 `json:"confirmedAt,omitempty"`
+}
+
+type fullNodeGroupSession struct {
+	group     FullNodeGroup
+	invited   map[string]bool
+	responses map[string]bool
+	createdAt time.Time
+}
+
+// FullNodeGroupTracker 는 PrepareGroup → PrepareGroupResponse → ConfirmGroup 을
+// (validator, StartRound, EndRound) 단위의 그룹 세션으로 묶습니다.
+type FullNodeGroupTracker struct {
+	mu       sync.RWMutex
+	sessions map[string]*fullNodeGroupSession
+}
+
+func NewFullNodeGroupTracker() *FullNodeGroupTracker {
+	return &FullNodeGroupTracker{
+		sessions: make(map[string]*fullNodeGroupSession),
+	}
+}
+
+func groupKey(prepare *fullnode_group.PrepareGroup) string {
+	return fmt.Sprintf("%x:%d-%d", []byte(prepare.ValidatorID), prepare.StartRound, prepare.EndRound)
+}
+
+func (t *FullNodeGroupTracker) Observe(msg *fullnode_group.FullNodesGroupMessage, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var session *fullNodeGroupSession
+	switch payload := msg.Payload.(type) {
+	case *fullnode_group.PrepareGroup:
+		session = t.sessionLocked(payload, meta.CaptureTime)
+		if session == nil {
+			return
+		}
+		if session.group.PreparedAt == 0 {
+			session.group.PreparedAt = meta.CaptureTime.UnixMicro()
+		}
+		session.invited[meta.DstIP] = true
+
+	case *fullnode_group.PrepareGroupResponse:
+		if payload.Req == nil {
+			return
+		}
+		session = t.sessionLocked(payload.Req, meta.CaptureTime)
+		if session == nil {
+			return
+		}
+		session.responses[hex.EncodeToString(payload.NodeID)] = payload.Accept
+
+	case *fullnode_group.ConfirmGroup:
+		if payload.Prepare == nil {
+			return
+		}
+		session = t.sessionLocked(payload.Prepare, meta.CaptureTime)
+		if session == nil {
+			return
+		}
+		session.group.Confirmed = true
+		session.group.ConfirmedAt = meta.CaptureTime.UnixMicro()
+		session.group.Peers = make([]FullNodeGroupMember, 0, len(payload.Peers))
+		for i, peer := range payload.Peers {
+			member := FullNodeGroupMember{NodeID: hex.EncodeToString(peer)}
+			if i < len(payload.NameRecords) {
+				if fields, ok := decodeNameRecordFields(payload.NameRecords[i]); ok {
+					member.IP = fields.ip.String()
+					member.Port = fields.port
+					member.Ports = fields.ports
+					member.Seq = fields.seq
+				}
+			}
+			session.group.Peers = append(session.group.Peers, member)
+		}
+
+	default:
+		return
+	}
+
+	publisher.Publish(util.FULLNODE_GROUP_EVENT, session.snapshot())
+}
+
+func (t *FullNodeGroupTracker) sessionLocked(prepare *fullnode_group.PrepareGroup, now time.Time) *fullNodeGroupSession {
+	key := groupKey(prepare)
+	if session, ok := t.sessions[key]; ok {
+		return session
+	}
+
+	if len(t.sessions) >= maxFullNodeGroups {
+		t.evictOldestLocked()
+	}
+	session := &fullNodeGroupSession{
+		group: FullNodeGroup{
+			ValidatorID:  hex.EncodeToString(prepare.ValidatorID),
+			MaxGroupSize: prepare.MaxGroupSize,
+			StartRound:   prepare.StartRound,
+			EndRound:     prepare.EndRound,
+		},
+		invited:   make(map[string]bool),
+		responses: make(map[string]bool),
+		createdAt: now,
+	}
+	t.sessions[key] = session
+	return session
+}
+
+func (t *FullNodeGroupTracker) evictOldestLocked() {
+	var oldestKey string
+	var oldest time.Time
+	for key, session := range t.sessions {
+		if oldestKey == "" || session.createdAt.Before(oldest) {
+			oldestKey, oldest = key, session.createdAt
+		}
+	}
+	delete(t.sessions, oldestKey)
+}
+
+func (s *fullNodeGroupSession) snapshot() FullNodeGroup {
+	group := s.group
+	group.Peers = append([]FullNodeGroupMember(nil), s.group.Peers...)
+	group.InvitedIPs = make([]string, 0, len(s.invited))
+	for ip := range s.invited {
+		group.InvitedIPs = append(group.InvitedIPs, ip)
+	}
+	group.Accepted, group.Rejected = []string{}, []string{}
+	for nodeID, accepted := range s.responses {
+		if accepted {
+			group.Accepted = append(group.Accepted, nodeID)
+		} else {
+			group.Rejected = append(group.Rejected, nodeID)
+		}
+	}
+	sort.Strings(group.InvitedIPs)
+	sort.Strings(group.Accepted)
+	sort.Strings(group.Rejected)
+	return group
+}
+
+// Groups 는 추적 중인 그룹을 돌려줍니다. activeOnly 이면 round 를 포함하는 확정된 그룹만 돌려줍니다.
+func (t *FullNodeGroupTracker) Groups(round util.Round, activeOnly bool) []FullNodeGroup {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	groups := make([]FullNodeGroup, 0, len(t.sessions))
+	for _, session := range t.sessions {
+		if activeOnly && (!session.group.Confirmed || round < session.group.StartRound || round > session.group.EndRound) {
+			continue
+		}
+		groups = append(groups, session.snapshot())
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].StartRound != groups[j].StartRound {
+			return groups[i].StartRound < groups[j].StartRound
+		}
+		return groups[i].ValidatorID < groups[j].ValidatorID
+	})
+	return groups
+}
@@ -0,0 +1,127 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"log"
+	"strings"
+	"sync"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const maxCachedLeaders = 4096
+
+type LeaderMismatch struct {
+	Round          util.Round `json:"round"`
+	Epoch          util.Epoch `json:"epoch"`
+	ProposalRound  util.Round `json:"proposalRound"`
+	BlockID        string     `json:"blockId"`
+	ObservedAuthor string     `json:"observedAuthor"`
+	ExpectedLeader string     `json:"expectedLeader"`
+	ExpectedStake  string     `json:"expectedStake"`
+	ExpectedSelf   bool       `json:"expectedSelf,omitempty"`
+	SrcIP          string     `json:"srcIp"`
+}
+
+type leaderKey struct {
+	epoch util.Epoch
+	round util.Round
+}
+
+// LeaderSchedule 은 검증자 집합으로부터 라운드별 리더를 필요할 때만 계산하고 캐시합니다.
+type LeaderSchedule struct {
+	mu             sync.Mutex
+	store          *ValidatorStore
+	cacheVersion   uint64
+	cache          map[leaderKey]util.Validator
+	mismatchRounds map[util.Round]bool
+}
+
+func NewLeaderSchedule(store *ValidatorStore) *LeaderSchedule {
+	return &LeaderSchedule{
+		store:          store,
+		cache:          make(map[leaderKey]util.Validator),
+		mismatchRounds: make(map[util.Round]bool),
+	}
+}
+
+// Leader 는 해당 에포크의 검증자 집합으로 계산한 라운드 리더를 돌려줍니다.
+func (s *LeaderSchedule) Leader(epoch util.Epoch, round util.Round) (util.Validator, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leaderLocked(epoch, round)
+}
+
+func (s *LeaderSchedule) leaderLocked(epoch util.Epoch, round util.Round) (util.Validator, bool) {
+	validators, ok := s.store.Set(epoch)
+	if !ok {
+		return util.Validator{}, false
+	}
+
+	// 파일이 다시 읽히면 이전 집합으로 계산한 리더는 버립니다.
+	if version := s.store.Version(); version != s.cacheVersion {
+		s.cacheVersion = version
+		s.cache = make(map[leaderKey]util.Validator)
+	}
+
+	key := leaderKey{epoch: epoch, round: round}
+	if leader, ok := s.cache[key]; ok {
+		return leader, true
+	}
+
+	leader, err := util.GetLeader(uint64(round), validators)
+	if err != nil {
+		log.Printf("[Leader] Error calculating leader for Round %d: %v", round, err)
+		return util.Validator{}, false
+	}
+
+	if len(s.cache) >= maxCachedLeaders {
+		s.cache = make(map[leaderKey]util.Validator)
+	}
+	s.cache[key] = leader
+	return leader, true
+}
+
+// ObserveProposal 은 블록 헤더의 작성자를 해당 블록 라운드의 예상 리더와 비교합니다.
+// 재제안은 원래 블록의 헤더를 그대로 싣고 오므로 ProposalRound 가 아닌 BlockRound 를 기준으로 합니다.
+func (s *LeaderSchedule) ObserveProposal(proposalRound util.Round, header *common.ConsensusBlockHeader, blockID util.BlockID, meta model.MessageMeta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expected, ok := s.leaderLocked(header.Epoch, header.BlockRound)
+	if !ok {
+		s.store.ReportUnknown(header.Epoch, header.BlockRound, meta.SrcIP)
+		return
+	}
+
+	observed := hex.EncodeToString(header.Author)
+	if normalizeNodeID(expected.NodeID) == observed {
+		return
+	}
+	if s.mismatchRounds[header.BlockRound] {
+		return
+	}
+	if len(s.mismatchRounds) >= maxCachedLeaders {
+		s.mismatchRounds = make(map[util.Round]bool)
+	}
+	s.mismatchRounds[header.BlockRound] = true
+
+	publisher.Publish(util.LEADER_MISMATCH_EVENT, LeaderMismatch{
+		Round:          header.BlockRound,
+		Epoch:          header.Epoch,
+		ProposalRound:  proposalRound,
+		BlockID:        blockID.Hex(),
+		ObservedAuthor: observed,
+		ExpectedLeader: expected.NodeID,
+		ExpectedStake:  expected.Stake,
+		ExpectedSelf:   localNode.IsSelf(expected.NodeID),
+		SrcIP:          meta.SrcIP,
+	})
+}
+
+func normalizeNodeID(nodeID string) string {
+	return strings.TrimPrefix(strings.ToLower(nodeID), "0x")
+}
@@ -0,0 +1,168 @@
+package tracker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	leaderScoreWindow       = 256
+	leaderScorePublishEvery = 60 * time.Second
+	maxScoredEpochs         = 4
+)
+
+type LeaderScore struct {
+	NodeID                string     `json:"nodeId"`
+	Stake                 string     `json:"stake"`
+	RoundsLed             int        `json:"roundsLed"`
+	ProposalsDelivered    int        `json:"proposalsDelivered"`
+	ProposalsMissed       int        `json:"proposalsMissed"`
+	MedianProposalDelayMs float64    `json:"medianProposalDelayMs"`
+	MedianProposalBytes   float64    `json:"medianProposalBytes"`
+	LastRound             util.Round `json:"lastRound"`
+}
+
+type LeaderScorecard struct {
+	Epoch  util.Epoch    `json:"epoch"`
+	Final  bool          `json:"final"` // 다음 에포크가 시작되어 더 이상 바뀌지 않는 점수표
+	Scores []LeaderScore `json:"scores"`
+}
+
+type leaderScoreState struct {
+	score  LeaderScore
+	delays *rollingWindow
+	sizes  *rollingWindow
+}
+
+// LeaderScoreTracker 는 마감된 라운드를 예상 리더 기준으로 모아 에포크별 점수표를 만듭니다.
+type LeaderScoreTracker struct {
+	mu          sync.Mutex
+	schedule    *LeaderSchedule
+	epochs      map[util.Epoch]map[string]*leaderScoreState
+	latestEpoch util.Epoch
+	lastPublish time.Time
+}
+
+func NewLeaderScoreTracker(schedule *LeaderSchedule) *LeaderScoreTracker {
+	return &LeaderScoreTracker{
+		schedule: schedule,
+		epochs:   make(map[util.Epoch]map[string]*leaderScoreState),
+	}
+}
+
+func (t *LeaderScoreTracker) ObserveRound(tl RoundTimeline) {
+	if tl.Epoch == 0 {
+		return
+	}
+	leader, ok := t.schedule.Leader(tl.Epoch, tl.Round)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if tl.Epoch > t.latestEpoch {
+		if t.latestEpoch != 0 {
+			t.publishLocked(t.latestEpoch, true)
+		}
+		t.latestEpoch = tl.Epoch
+		t.pruneEpochsLocked()
+	}
+
+	scores, ok := t.epochs[tl.Epoch]
+	if !ok {
+		scores = make(map[string]*leaderScoreState)
+		t.epochs[tl.Epoch] = scores
+	}
+	nodeID := normalizeNodeID(leader.NodeID)
+	state, ok := scores[nodeID]
+	if !ok {
+		state = &leaderScoreState{
+			score:  LeaderScore{NodeID: nodeID, Stake: leader.Stake},
+			delays: newRollingWindow(leaderScoreWindow),
+			sizes:  newRollingWindow(leaderScoreWindow),
+		}
+		scores[nodeID] = state
+	}
+
+	state.score.RoundsLed++
+	if tl.Round > state.score.LastRound {
+		state.score.LastRound = tl.Round
+	}
+	if tl.ProposalAt != 0 {
+		state.score.ProposalsDelivered++
+		if tl.ProposalDelayMs != 0 {
+			state.delays.Add(tl.ProposalDelayMs)
+		}
+		if tl.ProposalBytes != 0 {
+			state.sizes.Add(float64(tl.ProposalBytes))
+		}
+	}
+	// 복구된 라운드도 TC 로 끝난 것이므로 결과와 관계없이 TC 를 봤으면 놓친 것으로 셉니다.
+	if tl.TCAt != 0 {
+		state.score.ProposalsMissed++
+	}
+}
+
+func (t *LeaderScoreTracker) pruneEpochsLocked() {
+	for len(t.epochs) > maxScoredEpochs {
+		var oldest util.Epoch
+		for epoch := range t.epochs {
+			if oldest == 0 || epoch < oldest {
+				oldest = epoch
+			}
+		}
+		delete(t.epochs, oldest)
+	}
+}
+
+func (t *LeaderScoreTracker) scorecardLocked(epoch util.Epoch, final bool) LeaderScorecard {
+	card := LeaderScorecard{Epoch: epoch, Final: final}
+	for _, state := range t.epochs[epoch] {
+		score := state.score
+		score.MedianProposalDelayMs = state.delays.Percentile(50)
+		score.MedianProposalBytes = state.sizes.Percentile(50)
+		card.Scores = append(card.Scores, score)
+	}
+	sort.Slice(card.Scores, func(i, j int) bool {
+		return card.Scores[i].NodeID < card.Scores[j].NodeID
+	})
+	return card
+}
+
+func (t *LeaderScoreTracker) publishLocked(epoch util.Epoch, final bool) {
+	if _, ok := t.epochs[epoch]; !ok {
+		return
+	}
+	publisher.Publish(util.LEADER_SCORE_EVENT, t.scorecardLocked(epoch, final))
+}
+
+// Scorecard 는 지정한 에포크의 점수표를 돌려줍니다. epoch 가 0 이면 가장 최근 에포크입니다.
+func (t *LeaderScoreTracker) Scorecard(epoch util.Epoch) (LeaderScorecard, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if epoch == 0 {
+		epoch = t.latestEpoch
+	}
+	if _, ok := t.epochs[epoch]; !ok {
+		return LeaderScorecard{}, false
+	}
+	return t.scorecardLocked(epoch, epoch < t.latestEpoch), true
+}
+
+func (t *LeaderScoreTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.lastPublish) < leaderScorePublishEvery {
+		return
+	}
+	t.lastPublish = now
+	t.publishLocked(t.latestEpoch, false)
+}
@@ -0,0 +1,94 @@
+package tracker
+
+import (
+	"net"
+
+	"monad-flow/model/message/outbound_router/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	NameRecordInvalidSignature = "invalid_signature"
+	NameRecordNodeMismatch     = "node_mismatch"
+	NameRecordIPMismatch       = "ip_mismatch"
+	NameRecordStaleSeq         = "stale_seq"
+)
+
+type NameRecordAlert struct {
+	Reason        string `json:"reason"`
+	Source        string `json:"source"`
+	ClaimedNodeID string `json:"claimedNodeId,omitempty"`
+	SignerNodeID  string `json:"signerNodeId,omitempty"`
+	Version       uint8  `json:"version"`
+	RecordIP      string `json:"recordIp"`
+	PacketSrcIP   string `json:"packetSrcIp,omitempty"`
+	Seq           uint64 `json:"seq"`
+	KnownSeq      uint64 `json:"knownSeq,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+type nameRecordFields struct {
+	ip           net.IP
+	port         uint16           // V1 레코드에만 있습니다.
+	ports        map[uint8]uint16 // V2 레코드의 태그별 포트입니다.
+	capabilities uint64
+	seq          uint64
+}
+
+func decodeNameRecordFields(record *common.MonadNameRecord) (nameRecordFields, bool) {
+	var fields nameRecordFields
+	if record == nil || record.NameRecord == nil {
+		return fields, false
+	}
+
+	switch r := record.NameRecord.Record.(type) {
+	case *common.WireNameRecordV1:
+		fields.ip, fields.port, fields.seq = net.IP(r.IP), r.Port, r.Seq
+	case *common.WireNameRecordV2:
+		fields.ip, fields.capabilities, fields.seq = net.IP(r.IP), r.Capabilities, r.Seq
+		fields.ports = make(map[uint8]uint16, len(r.Ports))
+		for _, p := range r.Ports {
+			fields.ports[p.Tag] = p.Port
+		}
+	default:
+		return fields, false
+	}
+	return fields, true
+}
+
+// verifyNameRecord 는 레코드 서명자를 복구해 claimedNodeID(알 수 있는 경우)와 비교하고,
+// srcIP 가 주어지고 로컬 주소가 아니면 레코드가 광고하는 IP 와 패킷 출발지 IP 가 같은지도 확인합니다.
+// 서명이 유효하고 주장된 노드와 일치할 때만 서명자 NodeID 와 true 를 돌려줍니다.
+func verifyNameRecord(record *common.MonadNameRecord, fields nameRecordFields, claimedNodeID, srcIP, source string) (string, bool) {
+	alert := NameRecordAlert{
+		Source:        source,
+		ClaimedNodeID: claimedNodeID,
+		Version:       record.NameRecord.Version,
+		RecordIP:      fields.ip.String(),
+		PacketSrcIP:   srcIP,
+		Seq:           fields.seq,
+	}
+
+	signer, err := record.RecoverNodeID()
+	if err != nil {
+		alert.Reason = NameRecordInvalidSignature
+		alert.Error = err.Error()
+		publisher.Publish(util.NAME_RECORD_ALERT_EVENT, alert)
+		return "", false
+	}
+	alert.SignerNodeID = signer
+
+	if claimedNodeID != "" && claimedNodeID != signer {
+		alert.Reason = NameRecordNodeMismatch
+		publisher.Publish(util.NAME_RECORD_ALERT_EVENT, alert)
+		return "", false
+	}
+
+	// 우리 노드가 보낸 패킷이면 출발지가 사설 인터페이스 주소일 수 있어 비교하지 않습니다.
+	if srcIP != "" && !util.IsLocalIP(srcIP) && srcIP != alert.RecordIP {
+		alert.Reason = NameRecordIPMismatch
+		publisher.Publish(util.NAME_RECORD_ALERT_EVENT, alert)
+	}
+	return signer, true
+}
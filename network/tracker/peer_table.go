@@ -0,0 +1,221 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/common"
+	"monad-flow/model/message/outbound_router/fullnode_group"
+	"monad-flow/model/message/outbound_router/peer_discovery"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const maxPeerTableSize = 10000
+
+const (
+	PeerSourcePing   = "ping"
+	PeerSourceLookup = "lookup"
+	PeerSourceGroup  = "group"
+	PeerSourceChunk  = "chunk"
+)
+
+type PeerEntry struct {
+	NodeID       string           `json:"nodeId"`
+	IP           string           `json:"ip,omitempty"`
+	Port         uint16           `json:"port,omitempty"`
+	Ports        map[uint8]uint16 `json:"ports,omitempty"`
+	Capabilities uint64           `json:"capabilities"`
+	Seq          uint64           `json:"seq"`
+	Source       string           `json:"source"`
+	FirstSeen    int64            `json:"firstSeen"`
+	LastSeen     int64            `json:"lastSeen"`
+}
+
+type PeerTable struct {
+	mu    sync.RWMutex
+	peers map[string]*PeerEntry
+}
+
+func NewPeerTable() *PeerTable {
+	return &PeerTable{
+		peers: make(map[string]*PeerEntry),
+	}
+}
+
+func (t *PeerTable) ObservePeerDiscovery(msg *peer_discovery.PeerDiscoveryMessage, meta model.MessageMeta) {
+	switch payload := msg.Payload.(type) {
+	case *peer_discovery.Ping:
+		// Ping 에 실린 이름 레코드는 보낸 노드 자신의 것이므로 패킷 출발지와도 비교합니다.
+		if payload.LocalNameRecord != nil {
+			t.updateFromNameRecord(meta.Author, payload.LocalNameRecord, PeerSourcePing, meta.SrcIP, meta.CaptureTime)
+		}
+	case *peer_discovery.Pong:
+		if meta.Author != "" {
+			t.touch(meta.Author, payload.LocalRecordSeq, meta.CaptureTime)
+		}
+	case *peer_discovery.PeerLookupResponse:
+		for _, record := range payload.NameRecords {
+			t.updateFromNameRecord("", record, PeerSourceLookup, "", meta.CaptureTime)
+		}
+	}
+}
+
+func (t *PeerTable) ObserveFullNodesGroup(msg *fullnode_group.FullNodesGroupMessage, meta model.MessageMeta) {
+	confirm, ok := msg.Payload.(*fullnode_group.ConfirmGroup)
+	if !ok || len(confirm.Peers) != len(confirm.NameRecords) {
+		return
+	}
+	for i, peer := range confirm.Peers {
+		t.updateFromNameRecord(hex.EncodeToString(peer), confirm.NameRecords[i], PeerSourceGroup, "", meta.CaptureTime)
+	}
+}
+
+// ObserveChunk 는 복구된 청크 서명자로 피어를 갱신합니다. point-to-point 청크만 작성자가
+// 직접 보낸 것이므로 그때만 패킷 출발지를 작성자의 주소로 기록합니다.
+func (t *PeerTable) ObserveChunk(chunk *model.MonadChunkPacket, author string, captureTime time.Time) {
+	if author == "" {
+		return
+	}
+	src := chunk.Network.Ipv4.SrcIp
+	if chunk.Broadcast || chunk.SecondaryBroadcast || src == "" || util.IsLocalIP(src) {
+		t.touch(author, 0, captureTime)
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, created := t.entryLocked(author, captureTime)
+	if entry == nil {
+		return
+	}
+	// 서명된 이름 레코드에서 얻은 주소가 있으면 청크 관측값으로 덮어쓰지 않습니다.
+	if entry.Source != PeerSourceChunk && entry.IP != "" {
+		return
+	}
+	port := uint16(chunk.Network.Port.SrcPort)
+	if !created && entry.IP == src && entry.Port == port {
+		return
+	}
+	entry.IP = src
+	entry.Port = port
+	entry.Source = PeerSourceChunk
+	t.publishLocked(entry)
+}
+
+// updateFromNameRecord 는 서명이 검증된 레코드만 서명자 NodeID 기준으로 반영합니다.
+func (t *PeerTable) updateFromNameRecord(claimedNodeID string, record *common.MonadNameRecord, source, srcIP string, now time.Time) {
+	fields, ok := decodeNameRecordFields(record)
+	if !ok {
+		return
+	}
+	nodeID, ok := verifyNameRecord(record, fields, claimedNodeID, srcIP, source)
+	if !ok {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, created := t.entryLocked(nodeID, now)
+	if entry == nil {
+		return
+	}
+	// 이미 더 높은 seq 의 레코드를 알고 있다면 오래된 레코드입니다.
+	if !created && entry.Source != PeerSourceChunk && fields.seq < entry.Seq {
+		publisher.Publish(util.NAME_RECORD_ALERT_EVENT, NameRecordAlert{
+			Reason:       NameRecordStaleSeq,
+			Source:       source,
+			SignerNodeID: nodeID,
+			Version:      record.NameRecord.Version,
+			RecordIP:     fields.ip.String(),
+			PacketSrcIP:  srcIP,
+			Seq:          fields.seq,
+			KnownSeq:     entry.Seq,
+		})
+		return
+	}
+	ip := fields.ip.String()
+	changed := created || entry.IP != ip || entry.Seq != fields.seq || entry.Capabilities != fields.capabilities
+	entry.IP = ip
+	// V2 레코드에는 단일 포트가 없으므로 청크에서 알게 된 포트를 그대로 둡니다.
+	if fields.port != 0 {
+		entry.Port = fields.port
+	}
+	if fields.ports != nil {
+		entry.Ports = fields.ports
+	}
+	entry.Capabilities = fields.capabilities
+	entry.Seq = fields.seq
+	entry.Source = source
+	if changed {
+		t.publishLocked(entry)
+	}
+}
+
+func (t *PeerTable) touch(nodeID string, seq uint64, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, created := t.entryLocked(nodeID, now)
+	if entry == nil {
+		return
+	}
+	if seq > entry.Seq {
+		entry.Seq = seq
+	}
+	if created {
+		t.publishLocked(entry)
+	}
+}
+
+func (t *PeerTable) entryLocked(nodeID string, now time.Time) (*PeerEntry, bool) {
+	entry, ok := t.peers[nodeID]
+	if ok {
+		entry.LastSeen = now.UnixMicro()
+		return entry, false
+	}
+	if len(t.peers) >= maxPeerTableSize {
+		return nil, false
+	}
+	entry = &PeerEntry{
+		NodeID:    nodeID,
+		FirstSeen: now.UnixMicro(),
+		LastSeen:  now.UnixMicro(),
+	}
+	t.peers[nodeID] = entry
+	return entry, true
+}
+
+func (t *PeerTable) publishLocked(entry *PeerEntry) {
+	publisher.Publish(util.PEER_TABLE_EVENT, *entry)
+}
+
+func (t *PeerTable) Get(nodeID string) (PeerEntry, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	entry, ok := t.peers[nodeID]
+	if !ok {
+		return PeerEntry{}, false
+	}
+	return *entry, true
+}
+
+func (t *PeerTable) Snapshot() []PeerEntry {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	entries := make([]PeerEntry, 0, len(t.peers))
+	for _, entry := range t.peers {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].NodeID < entries[j].NodeID
+	})
+	return entries
+}
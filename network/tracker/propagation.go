@@ -0,0 +1,247 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	propagationWindow       = 512
+	propagationPublishEvery = 30 * time.Second
+	// 지연 최솟값을 모으는 구간과, 오프셋 추정에 쓰는 최근 구간 수
+	minDelayBucket        = 60 * time.Second
+	minDelayBuckets       = 10
+	maxSeenHashes         = 1024
+	maxPropagationSenders = 4096
+)
+
+const (
+	OffsetBasisMinDelay    = "min_delay"     // 최소 지연을 그대로 오프셋으로 봄 (보정 지연 = 최소 대비 추가 지연)
+	OffsetBasisMinDelayRTT = "min_delay_rtt" // 최소 지연에서 RTT/2 를 전파 시간으로 빼서 오프셋으로 봄
+)
+
+type SenderPropagation struct {
+	Author       string  `json:"author"`
+	IP           string  `json:"ip,omitempty"`
+	Samples      int     `json:"samples"`
+	P50Ms        float64 `json:"p50Ms"`
+	P90Ms        float64 `json:"p90Ms"`
+	MinDelayMs   float64 `json:"minDelayMs"`
+	RTTMs        float64 `json:"rttMs,omitempty"`
+	OffsetMs     float64 `json:"offsetMs"`
+	OffsetBasis  string  `json:"offsetBasis"`
+	CorrectedP50 float64 `json:"correctedP50Ms"`
+	CorrectedP90 float64 `json:"correctedP90Ms"`
+}
+
+type ProposalPropagation struct {
+	Round       util.Round  `json:"round"`
+	SeqNum      util.SeqNum `json:"seqNum"`
+	BlockID     string      `json:"blockId"`
+	Author      string      `json:"author"`
+	Self        bool        `json:"self,omitempty"`
+	LatencyMs   float64     `json:"latencyMs"` // 캡처 시각 - 헤더 TimestampNS
+	OffsetMs    float64     `json:"offsetMs"`
+	CorrectedMs float64     `json:"correctedMs"`
+	OffsetKnown bool        `json:"offsetKnown"`
+}
+
+type senderClock struct {
+	ip          string
+	latencies   *rollingWindow
+	bucketStart time.Time
+	bucketMin   float64
+	minima      []float64
+	seen        map[[20]byte]bool
+}
+
+// PropagationTracker 는 청크의 송신 측 TimestampMs 와 캡처 시각의 차이로 전파 지연을 재고,
+// 구간별 최소 지연으로 피어별 시계 오프셋을 추정해 보정한 지연을 보고합니다.
+type PropagationTracker struct {
+	mu          sync.Mutex
+	senders     map[string]*senderClock
+	rtts        map[string]float64
+	lastPublish time.Time
+}
+
+func NewPropagationTracker() *PropagationTracker {
+	return &PropagationTracker{
+		senders: make(map[string]*senderClock),
+		rtts:    make(map[string]float64),
+	}
+}
+
+func (t *PropagationTracker) ObservePing(ip string, rtt time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.rtts) < maxPropagationSenders {
+		t.rtts[ip] = float64(rtt.Microseconds()) / 1000.0
+	} else if _, ok := t.rtts[ip]; ok {
+		t.rtts[ip] = float64(rtt.Microseconds()) / 1000.0
+	}
+}
+
+// ObserveChunk 는 메시지마다 처음 받은 청크만 표본으로 씁니다. 청크 수가 많은 메시지가 통계를 좌우하지 않게 하기 위함입니다.
+func (t *PropagationTracker) ObserveChunk(chunk *model.MonadChunkPacket, author string, captureTime time.Time) {
+	if author == "" || chunk.TimestampMs == 0 {
+		return
+	}
+	latency := float64(captureTime.UnixMicro())/1000.0 - float64(chunk.TimestampMs)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	clock, ok := t.senders[author]
+	if !ok {
+		if len(t.senders) >= maxPropagationSenders {
+			return
+		}
+		clock = &senderClock{
+			latencies: newRollingWindow(propagationWindow),
+			seen:      make(map[[20]byte]bool),
+		}
+		t.senders[author] = clock
+	}
+	if src := chunk.Network.Ipv4.SrcIp; !chunk.Broadcast && !chunk.SecondaryBroadcast && src != "" {
+		clock.ip = src
+	}
+
+	if clock.seen[chunk.AppMessageHash] {
+		return
+	}
+	if len(clock.seen) >= maxSeenHashes {
+		clock.seen = make(map[[20]byte]bool)
+	}
+	clock.seen[chunk.AppMessageHash] = true
+
+	clock.latencies.Add(latency)
+	clock.addMinimum(latency, captureTime)
+}
+
+func (c *senderClock) addMinimum(latency float64, now time.Time) {
+	if c.bucketStart.IsZero() || now.Sub(c.bucketStart) >= minDelayBucket {
+		if !c.bucketStart.IsZero() {
+			c.minima = append(c.minima, c.bucketMin)
+			if len(c.minima) > minDelayBuckets {
+				c.minima = c.minima[1:]
+			}
+		}
+		c.bucketStart = now
+		c.bucketMin = latency
+		return
+	}
+	if latency < c.bucketMin {
+		c.bucketMin = latency
+	}
+}
+
+// minDelay 는 최근 구간 최솟값들의 중앙값입니다. 한 구간의 이상치나 시계 변화에 덜 흔들립니다.
+func (c *senderClock) minDelay() (float64, bool) {
+	values := append([]float64(nil), c.minima...)
+	if !c.bucketStart.IsZero() {
+		values = append(values, c.bucketMin)
+	}
+	if len(values) == 0 {
+		return 0, false
+	}
+	sort.Float64s(values)
+	return values[len(values)/2], true
+}
+
+func (t *PropagationTracker) offsetLocked(author string) (offset, minDelay, rtt float64, basis string, ok bool) {
+	clock, exists := t.senders[author]
+	if !exists {
+		return 0, 0, 0, "", false
+	}
+	minDelay, ok = clock.minDelay()
+	if !ok {
+		return 0, 0, 0, "", false
+	}
+	if r, hasRTT := t.rtts[clock.ip]; hasRTT && clock.ip != "" {
+		return minDelay - r/2, minDelay, r, OffsetBasisMinDelayRTT, true
+	}
+	return minDelay, minDelay, 0, OffsetBasisMinDelay, true
+}
+
+// ObserveProposal 은 헤더 TimestampNS 기준 지연을 작성자의 시계 오프셋으로 보정해 보고합니다.
+func (t *PropagationTracker) ObserveProposal(header *common.ConsensusBlockHeader, blockID util.BlockID, meta model.MessageMeta) {
+	proposedMs := float64(header.TimestampNS.Uint64()) / 1e6
+	if proposedMs == 0 {
+		return
+	}
+	author := hex.EncodeToString(header.Author)
+
+	t.mu.Lock()
+	offset, _, _, _, ok := t.offsetLocked(author)
+	t.mu.Unlock()
+
+	latency := float64(meta.CaptureTime.UnixMicro())/1000.0 - proposedMs
+	report := ProposalPropagation{
+		Round:       header.BlockRound,
+		SeqNum:      header.SeqNum,
+		BlockID:     blockID.Hex(),
+		Author:      author,
+		Self:        localNode.IsSelf(author),
+		LatencyMs:   latency,
+		CorrectedMs: latency,
+		OffsetKnown: ok,
+	}
+	if ok {
+		report.OffsetMs = offset
+		report.CorrectedMs = latency - offset
+	}
+	publisher.Publish(util.PROPOSAL_PROPAGATION_EVENT, report)
+}
+
+func (t *PropagationTracker) snapshotLocked() []SenderPropagation {
+	stats := make([]SenderPropagation, 0, len(t.senders))
+	for author, clock := range t.senders {
+		offset, minDelay, rtt, basis, ok := t.offsetLocked(author)
+		if !ok {
+			continue
+		}
+		p50 := clock.latencies.Percentile(50)
+		p90 := clock.latencies.Percentile(90)
+		stats = append(stats, SenderPropagation{
+			Author:       author,
+			IP:           clock.ip,
+			Samples:      clock.latencies.Len(),
+			P50Ms:        p50,
+			P90Ms:        p90,
+			MinDelayMs:   minDelay,
+			RTTMs:        rtt,
+			OffsetMs:     offset,
+			OffsetBasis:  basis,
+			CorrectedP50: p50 - offset,
+			CorrectedP90: p90 - offset,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].CorrectedP50 > stats[j].CorrectedP50
+	})
+	return stats
+}
+
+func (t *PropagationTracker) Snapshot() []SenderPropagation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshotLocked()
+}
+
+func (t *PropagationTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if now.Sub(t.lastPublish) < propagationPublishEvery || len(t.senders) == 0 {
+		return
+	}
+	t.lastPublish = now
+	publisher.Publish(util.PROPAGATION_STATS_EVENT, t.snapshotLocked())
+}
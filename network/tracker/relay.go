@@ -0,0 +1,121 @@
+package tracker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	// 마지막 청크 이후 이만큼 조용하면 메시지의 중계가 끝난 것으로 봅니다.
+	relayIdleTimeout   = 2 * time.Second
+	maxTrackedMessages = 8192
+)
+
+type RelayLatency struct {
+	AppMessageHash   string  `json:"appMessageHash"`
+	Author           string  `json:"author,omitempty"`
+	IngressChunks    int     `json:"ingressChunks"`
+	EgressChunks     int     `json:"egressChunks"`
+	FanOut           int     `json:"fanOut"` // 송신 청크의 서로 다른 목적지 수
+	FirstIngressAt   int64   `json:"firstIngressAt"`
+	FirstEgressAt    int64   `json:"firstEgressAt"`
+	LastEgressAt     int64   `json:"lastEgressAt"`
+	ToFirstEgressMs  float64 `json:"toFirstEgressMs"`
+	ToLastEgressMs   float64 `json:"toLastEgressMs"`
+	SecondaryRelayed bool    `json:"secondaryRelayed,omitempty"`
+}
+
+type relayState struct {
+	author        string
+	ingressChunks int
+	egressChunks  int
+	destinations  map[string]bool
+	firstIngress  time.Time
+	firstEgress   time.Time
+	lastEgress    time.Time
+	lastActivity  time.Time
+	secondary     bool
+}
+
+// RelayTracker 는 같은 AppMessageHash 의 청크가 들어온 뒤 우리 노드가 다시 내보내기까지의 지연을 잽니다.
+// 출발지가 로컬 주소인 청크를 송신으로 봅니다.
+type RelayTracker struct {
+	mu       sync.Mutex
+	messages map[[20]byte]*relayState
+}
+
+func NewRelayTracker() *RelayTracker {
+	return &RelayTracker{
+		messages: make(map[[20]byte]*relayState),
+	}
+}
+
+func (t *RelayTracker) ObserveChunk(chunk *model.MonadChunkPacket, author string, captureTime time.Time) {
+	src := chunk.Network.Ipv4.SrcIp
+	egress := src != "" && util.IsLocalIP(src)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.messages[chunk.AppMessageHash]
+	if !ok {
+		// 먼저 들어온 청크가 없는 송신은 우리 노드가 만든 메시지이므로 추적하지 않습니다.
+		if egress || len(t.messages) >= maxTrackedMessages {
+			return
+		}
+		state = &relayState{
+			author:       author,
+			destinations: make(map[string]bool),
+			firstIngress: captureTime,
+		}
+		t.messages[chunk.AppMessageHash] = state
+	}
+	state.lastActivity = captureTime
+
+	if !egress {
+		state.ingressChunks++
+		return
+	}
+	state.egressChunks++
+	if state.firstEgress.IsZero() {
+		state.firstEgress = captureTime
+	}
+	state.lastEgress = captureTime
+	state.destinations[chunk.Network.Ipv4.DstIp] = true
+	if chunk.SecondaryBroadcast {
+		state.secondary = true
+	}
+}
+
+func (t *RelayTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for hash, state := range t.messages {
+		if now.Sub(state.lastActivity) < relayIdleTimeout {
+			continue
+		}
+		delete(t.messages, hash)
+		if state.egressChunks == 0 {
+			continue
+		}
+		publisher.Publish(util.RELAY_LATENCY_EVENT, RelayLatency{
+			AppMessageHash:   fmt.Sprintf("0x%x", hash),
+			Author:           state.author,
+			IngressChunks:    state.ingressChunks,
+			EgressChunks:     state.egressChunks,
+			FanOut:           len(state.destinations),
+			FirstIngressAt:   state.firstIngress.UnixMicro(),
+			FirstEgressAt:    state.firstEgress.UnixMicro(),
+			LastEgressAt:     state.lastEgress.UnixMicro(),
+			ToFirstEgressMs:  float64(state.firstEgress.Sub(state.firstIngress).Microseconds()) / 1000.0,
+			ToLastEgressMs:   float64(state.lastEgress.Sub(state.firstIngress).Microseconds()) / 1000.0,
+			SecondaryRelayed: state.secondary,
+		})
+	}
+}
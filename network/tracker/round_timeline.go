@@ -0,0 +1,399 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	// 라운드 r 은 r+2 라운드가 시작되었거나 roundFinalizeDelay 동안 활동이 없으면 마감합니다.
+	roundFinalizeDelay = 5 * time.Second
+	maxOpenRounds      = 1024
+)
+
+const (
+	RoundOutcomeQC        = "qc"
+	RoundOutcomeTimedOut  = "timed_out"
+	RoundOutcomeRecovered = "recovered"
+	RoundOutcomeUnknown   = "unknown"
+
+	CertSourceProposal      = "proposal"
+	CertSourceAdvanceRound  = "advance_round"
+	CertSourceTimeout       = "timeout"
+	CertSourceRoundRecovery = "round_recovery"
+)
+
+type RoundTimeline struct {
+	Round          util.Round  `json:"round"`
+	Epoch          util.Epoch  `json:"epoch"`
+	Leader         string      `json:"leader,omitempty"`
+	SelfLeader     bool        `json:"selfLeader,omitempty"`
+	BlockID        string      `json:"blockId,omitempty"`
+	SeqNum         util.SeqNum `json:"seqNum,omitempty"`
+	StartedAt      int64       `json:"startedAt,omitempty"`
+	ProposalAt     int64       `json:"proposalAt,omitempty"`
+	ProposalBytes  int         `json:"proposalBytes,omitempty"`
+	FirstVoteAt    int64       `json:"firstVoteAt,omitempty"`
+	LastVoteAt     int64       `json:"lastVoteAt,omitempty"`
+	Votes          int         `json:"votes"`
+	QCAt           int64       `json:"qcAt,omitempty"`
+	QCSource       string      `json:"qcSource,omitempty"`
+	FirstTimeoutAt int64       `json:"firstTimeoutAt,omitempty"`
+	LastTimeoutAt  int64       `json:"lastTimeoutAt,omitempty"`
+	Timeouts       int         `json:"timeouts"`
+	TCAt           int64       `json:"tcAt,omitempty"`
+	TCSource       string      `json:"tcSource,omitempty"`
+	NoEndorsements int         `json:"noEndorsements"`
+	RecoveryAt     int64       `json:"recoveryAt,omitempty"`
+	Outcome        string      `json:"outcome"`
+
+	ProposalDelayMs       float64 `json:"proposalDelayMs,omitempty"`
+	ProposalToFirstVoteMs float64 `json:"proposalToFirstVoteMs,omitempty"`
+	ProposalToLastVoteMs  float64 `json:"proposalToLastVoteMs,omitempty"`
+	ProposalToQCMs        float64 `json:"proposalToQcMs,omitempty"`
+
+	VoteLatencies []VoteLatency `json:"voteLatencies,omitempty"`
+}
+
+// VoteLatency 는 제안 도착 후 해당 투표자의 투표가 보이기까지 걸린 시간입니다.
+// 투표가 제안보다 먼저 보였다면 음수입니다.
+type VoteLatency struct {
+	Voter     string  `json:"voter"`
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+type roundState struct {
+	timeline     RoundTimeline
+	voters       map[string]int64 // 투표자별 첫 투표 도착 시각 (UnixMicro)
+	timeoutSeen  map[string]bool
+	lastActivity time.Time
+}
+
+type RoundTimelineTracker struct {
+	mu               sync.Mutex
+	rounds           map[util.Round]*roundState
+	maxRound         util.Round
+	finalizedThrough util.Round
+	lastFinalized    RoundTimeline
+	onFinalize       []func(RoundTimeline)
+	// 락을 잡은 채 마감된 라운드로, 락을 푼 뒤 onFinalize 에 넘깁니다.
+	pending []RoundTimeline
+	// 여러 고루틴이 마감 결과를 넘길 때도 마감 순서를 지키도록 콜백 호출을 직렬화합니다.
+	notifyMu sync.Mutex
+}
+
+func NewRoundTimelineTracker() *RoundTimelineTracker {
+	return &RoundTimelineTracker{
+		rounds: make(map[util.Round]*roundState),
+	}
+}
+
+// OnFinalize 는 라운드가 마감될 때마다 호출될 함수를 등록합니다. 트래커 락을 푼 뒤 마감 순서대로 호출됩니다.
+func (t *RoundTimelineTracker) OnFinalize(fn func(RoundTimeline)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onFinalize = append(t.onFinalize, fn)
+}
+
+// roundLocked 는 아직 마감되지 않은 라운드의 상태를 돌려줍니다. 마감된 라운드는 nil 입니다.
+func (t *RoundTimelineTracker) roundLocked(round util.Round, epoch util.Epoch, now time.Time) *roundState {
+	if round == 0 || (t.finalizedThrough != 0 && round <= t.finalizedThrough) {
+		return nil
+	}
+	state, ok := t.rounds[round]
+	if !ok {
+		if len(t.rounds) >= maxOpenRounds {
+			t.finalizeThroughLocked(t.oldestRoundLocked())
+		}
+		state = &roundState{
+			timeline:    RoundTimeline{Round: round},
+			voters:      make(map[string]int64),
+			timeoutSeen: make(map[string]bool),
+		}
+		t.rounds[round] = state
+	}
+	if epoch != 0 {
+		state.timeline.Epoch = epoch
+	}
+	state.lastActivity = now
+	return state
+}
+
+// unlockAndNotify 는 락을 풀고, 그동안 마감된 라운드를 등록된 함수에 넘깁니다.
+func (t *RoundTimelineTracker) unlockAndNotify() {
+	finalized := t.pending
+	t.pending = nil
+	if len(finalized) == 0 {
+		t.mu.Unlock()
+		return
+	}
+	t.notifyMu.Lock()
+	hooks := t.onFinalize
+	t.mu.Unlock()
+	defer t.notifyMu.Unlock()
+
+	for _, timeline := range finalized {
+		for _, fn := range hooks {
+			fn(timeline)
+		}
+	}
+}
+
+// advanceLocked 는 QC 나 TC 로 라운드가 시작된 것이 확인될 때만 maxRound 를 올립니다.
+// 동기화가 어긋난 노드의 타임아웃처럼 먼 라운드를 담은 메시지 하나로 열린 라운드가 한꺼번에 마감되지 않게 합니다.
+func (t *RoundTimelineTracker) advanceLocked(round util.Round) {
+	if round > t.maxRound {
+		t.maxRound = round
+	}
+}
+
+func (t *RoundTimelineTracker) ObserveProposal(round util.Round, epoch util.Epoch, header *common.ConsensusBlockHeader, blockID util.BlockID, lastRoundTC *common.TimeoutCertificate, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.unlockAndNotify()
+
+	at := meta.CaptureTime.UnixMicro()
+	if state := t.roundLocked(round, epoch, meta.CaptureTime); state != nil && state.timeline.ProposalAt == 0 {
+		state.timeline.ProposalAt = at
+		state.timeline.Leader = hex.EncodeToString(header.Author)
+		state.timeline.BlockID = blockID.Hex()
+		state.timeline.SeqNum = header.SeqNum
+		state.timeline.ProposalBytes = meta.Size
+	}
+
+	// 제안 헤더의 QC 는 직전 블록 라운드에 QC 가 형성되었음을 뜻합니다.
+	t.recordQCLocked(header.QC.Info.Round, header.QC.Info.Epoch, CertSourceProposal, meta.CaptureTime)
+	if lastRoundTC != nil {
+		t.recordTCLocked(lastRoundTC.Round, lastRoundTC.Epoch, CertSourceProposal, meta.CaptureTime)
+	}
+	t.finalizeThroughLocked(t.maxRound - 2)
+}
+
+func (t *RoundTimelineTracker) ObserveVote(round util.Round, epoch util.Epoch, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.unlockAndNotify()
+
+	state := t.roundLocked(round, epoch, meta.CaptureTime)
+	if state == nil {
+		return
+	}
+	voter := meta.Author
+	if voter == "" {
+		voter = meta.SrcIP
+	}
+	if _, ok := state.voters[voter]; ok {
+		return
+	}
+	at := meta.CaptureTime.UnixMicro()
+	state.voters[voter] = at
+	state.timeline.Votes++
+
+	if state.timeline.FirstVoteAt == 0 {
+		state.timeline.FirstVoteAt = at
+	}
+	state.timeline.LastVoteAt = at
+}
+
+func (t *RoundTimelineTracker) ObserveTimeout(round util.Round, epoch util.Epoch, lastRoundCert *common.RoundCertificateWrapper, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.unlockAndNotify()
+
+	if state := t.roundLocked(round, epoch, meta.CaptureTime); state != nil {
+		sender := meta.Author
+		if sender == "" {
+			sender = meta.SrcIP
+		}
+		if !state.timeoutSeen[sender] {
+			state.timeoutSeen[sender] = true
+			state.timeline.Timeouts++
+			at := meta.CaptureTime.UnixMicro()
+			if state.timeline.FirstTimeoutAt == 0 {
+				state.timeline.FirstTimeoutAt = at
+			}
+			state.timeline.LastTimeoutAt = at
+		}
+	}
+	t.recordRoundCertificateLocked(lastRoundCert, CertSourceTimeout, meta.CaptureTime)
+}
+
+func (t *RoundTimelineTracker) ObserveAdvanceRound(cert *common.RoundCertificateWrapper, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.unlockAndNotify()
+
+	t.recordRoundCertificateLocked(cert, CertSourceAdvanceRound, meta.CaptureTime)
+}
+
+func (t *RoundTimelineTracker) ObserveRoundRecovery(round util.Round, epoch util.Epoch, tc *common.TimeoutCertificate, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.unlockAndNotify()
+
+	if tc != nil {
+		t.recordTCLocked(tc.Round, tc.Epoch, CertSourceRoundRecovery, meta.CaptureTime)
+	}
+	if state := t.roundLocked(round, epoch, meta.CaptureTime); state != nil && state.timeline.RecoveryAt == 0 {
+		state.timeline.RecoveryAt = meta.CaptureTime.UnixMicro()
+	}
+}
+
+func (t *RoundTimelineTracker) ObserveNoEndorsement(round util.Round, epoch util.Epoch, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.unlockAndNotify()
+
+	if state := t.roundLocked(round, epoch, meta.CaptureTime); state != nil {
+		state.timeline.NoEndorsements++
+	}
+}
+
+func (t *RoundTimelineTracker) recordRoundCertificateLocked(cert *common.RoundCertificateWrapper, source string, now time.Time) {
+	if cert == nil {
+		return
+	}
+	switch c := cert.Certificate.(type) {
+	case *common.RoundCertificateQC:
+		if c.QC != nil {
+			t.recordQCLocked(c.QC.Info.Round, c.QC.Info.Epoch, source, now)
+		}
+	case *common.RoundCertificateTC:
+		if c.TC != nil {
+			t.recordTCLocked(c.TC.Round, c.TC.Epoch, source, now)
+		}
+	}
+}
+
+func (t *RoundTimelineTracker) recordQCLocked(round util.Round, epoch util.Epoch, source string, now time.Time) {
+	if state := t.roundLocked(round, epoch, now); state != nil && state.timeline.QCAt == 0 {
+		state.timeline.QCAt = now.UnixMicro()
+		state.timeline.QCSource = source
+	}
+	t.advanceLocked(round + 1)
+	// 다음 라운드 제안에 실려 온 QC 는 그 제안과 같은 시각이라 라운드 시작 시각으로 쓸 수 없습니다.
+	if source != CertSourceProposal {
+		t.recordRoundStartLocked(round+1, epoch, now)
+	}
+}
+
+func (t *RoundTimelineTracker) recordTCLocked(round util.Round, epoch util.Epoch, source string, now time.Time) {
+	if state := t.roundLocked(round, epoch, now); state != nil && state.timeline.TCAt == 0 {
+		state.timeline.TCAt = now.UnixMicro()
+		state.timeline.TCSource = source
+	}
+	t.advanceLocked(round + 1)
+	t.recordRoundStartLocked(round+1, epoch, now)
+}
+
+func (t *RoundTimelineTracker) recordRoundStartLocked(round util.Round, epoch util.Epoch, now time.Time) {
+	if state := t.roundLocked(round, epoch, now); state != nil && state.timeline.StartedAt == 0 {
+		state.timeline.StartedAt = now.UnixMicro()
+	}
+}
+
+func (t *RoundTimelineTracker) oldestRoundLocked() util.Round {
+	var oldest util.Round
+	for round := range t.rounds {
+		if oldest == 0 || round < oldest {
+			oldest = round
+		}
+	}
+	return oldest
+}
+
+// finalizeThroughLocked 는 through 이하의 열린 라운드를 라운드 순서대로 마감해 내보냅니다.
+func (t *RoundTimelineTracker) finalizeThroughLocked(through util.Round) {
+	if through == 0 || through > t.maxRound || through <= t.finalizedThrough {
+		return
+	}
+
+	var rounds []util.Round
+	for round := range t.rounds {
+		if round <= through {
+			rounds = append(rounds, round)
+		}
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i] < rounds[j] })
+
+	for _, round := range rounds {
+		state := t.rounds[round]
+		delete(t.rounds, round)
+
+		timeline := state.finalize(t.lastFinalized)
+		timeline.SelfLeader = localNode.IsSelf(timeline.Leader)
+		t.lastFinalized = timeline
+		publisher.Publish(util.ROUND_TIMELINE_EVENT, timeline)
+		t.pending = append(t.pending, timeline)
+	}
+	t.finalizedThrough = through
+}
+
+// finalize 는 결과를 판정하고 지연 값을 계산합니다. prev 는 직전에 마감된 라운드입니다.
+func (s *roundState) finalize(prev RoundTimeline) RoundTimeline {
+	tl := s.timeline
+
+	// QC/TC 를 따로 보지 못했다면 직전 라운드의 마지막 투표 시각을 라운드 시작으로 봅니다.
+	if tl.StartedAt == 0 && prev.Round+1 == tl.Round && prev.LastVoteAt != 0 && prev.LastVoteAt <= tl.ProposalAt {
+		tl.StartedAt = prev.LastVoteAt
+	}
+	switch {
+	case tl.QCAt != 0:
+		tl.Outcome = RoundOutcomeQC
+	case tl.TCAt != 0 && (tl.RecoveryAt != 0 || tl.NoEndorsements > 0):
+		tl.Outcome = RoundOutcomeRecovered
+	case tl.TCAt != 0:
+		tl.Outcome = RoundOutcomeTimedOut
+	case tl.RecoveryAt != 0:
+		tl.Outcome = RoundOutcomeRecovered
+	default:
+		tl.Outcome = RoundOutcomeUnknown
+	}
+
+	if tl.ProposalAt != 0 {
+		if tl.StartedAt != 0 && tl.StartedAt <= tl.ProposalAt {
+			tl.ProposalDelayMs = float64(tl.ProposalAt-tl.StartedAt) / 1000.0
+		}
+		if tl.FirstVoteAt != 0 {
+			tl.ProposalToFirstVoteMs = float64(tl.FirstVoteAt-tl.ProposalAt) / 1000.0
+			tl.ProposalToLastVoteMs = float64(tl.LastVoteAt-tl.ProposalAt) / 1000.0
+		}
+		if tl.QCAt != 0 {
+			tl.ProposalToQCMs = float64(tl.QCAt-tl.ProposalAt) / 1000.0
+		}
+		tl.VoteLatencies = make([]VoteLatency, 0, len(s.voters))
+		for voter, at := range s.voters {
+			tl.VoteLatencies = append(tl.VoteLatencies, VoteLatency{
+				Voter:     voter,
+				LatencyMs: float64(at-tl.ProposalAt) / 1000.0,
+			})
+		}
+		sort.Slice(tl.VoteLatencies, func(i, j int) bool {
+			return tl.VoteLatencies[i].LatencyMs < tl.VoteLatencies[j].LatencyMs
+		})
+	}
+	return tl
+}
+
+// Sweep 은 새 라운드가 더 이상 관측되지 않을 때에도 오래된 라운드를 마감합니다.
+func (t *RoundTimelineTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.unlockAndNotify()
+
+	var stale util.Round
+	for round, state := range t.rounds {
+		if now.Sub(state.lastActivity) < roundFinalizeDelay {
+			continue
+		}
+		// QC/TC 로 시작이 확인되지 않은 라운드는 어긋난 노드가 보낸 것이므로 마감하지 않고 버립니다.
+		if round > t.maxRound {
+			delete(t.rounds, round)
+			continue
+		}
+		if round > stale {
+			stale = round
+		}
+	}
+	t.finalizeThroughLocked(stale)
+}
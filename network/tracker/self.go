@@ -0,0 +1,211 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/publisher"
+	"monad-flow/util"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	selfMetricsWindow       = 512
+	selfMetricsPublishEvery = 30 * time.Second
+	selfResolveInterval     = 10 * time.Second
+)
+
+type SelfMetrics struct {
+	NodeID              string  `json:"nodeId"`
+	BLSPubkey           string  `json:"blsPubkey,omitempty"`
+	VotesSent           int     `json:"votesSent"`
+	VoteLatencyP50Ms    float64 `json:"voteLatencyP50Ms"`
+	VoteLatencyP90Ms    float64 `json:"voteLatencyP90Ms"`
+	RoundsLed           int     `json:"roundsLed"`
+	ProposalsSent       int     `json:"proposalsSent"`
+	ProposalEgressP50Ms float64 `json:"proposalEgressP50Ms"` // 헤더 TimestampNS 부터 우리 송신 캡처까지
+	ProposalToQCP50Ms   float64 `json:"proposalToQcP50Ms"`
+	QCsSeen             int     `json:"qcsSeen"`
+	QCsSigned           int     `json:"qcsSigned"`
+	QCShare             float64 `json:"qcShare"`
+}
+
+// SelfTracker 는 사이드카 옆 노드의 관점에서 투표 송신 지연, 제안 전파, QC 참여율을 잽니다.
+type SelfTracker struct {
+	mu          sync.Mutex
+	identity    util.LocalIdentity
+	loaded      bool
+	nodeID      string
+	lastResolve time.Time
+	store       *ValidatorStore
+
+	metrics        SelfMetrics
+	voteLatency    *rollingWindow
+	proposalEgress *rollingWindow
+	proposalToQC   *rollingWindow
+	lastQCRound    util.Round
+	lastPublish    time.Time
+}
+
+func NewSelfTracker(store *ValidatorStore) *SelfTracker {
+	return &SelfTracker{
+		store:          store,
+		voteLatency:    newRollingWindow(selfMetricsWindow),
+		proposalEgress: newRollingWindow(selfMetricsWindow),
+		proposalToQC:   newRollingWindow(selfMetricsWindow),
+	}
+}
+
+// nodeIDLocked 는 secp 공개키가 주어지지 않았다면 BLS 공개키를 검증자 집합의 cert_pubkey 와 맞춰 NodeID 를 찾습니다.
+func (s *SelfTracker) nodeIDLocked() string {
+	if !s.loaded {
+		s.loaded = true
+		s.identity = util.GetLocalIdentity()
+		s.nodeID = s.identity.SecpPubkey
+	}
+	if s.nodeID != "" || s.identity.BLSPubkey == "" {
+		return s.nodeID
+	}
+
+	now := time.Now()
+	if now.Sub(s.lastResolve) < selfResolveInterval {
+		return ""
+	}
+	s.lastResolve = now
+	for _, epoch := range s.store.Epochs() {
+		validators, _ := s.store.Set(epoch)
+		for _, v := range validators {
+			if normalizeNodeID(v.CertPubkey) == s.identity.BLSPubkey {
+				s.nodeID = normalizeNodeID(v.NodeID)
+				return s.nodeID
+			}
+		}
+	}
+	return ""
+}
+
+// IsSelf 는 nodeID 가 로컬 노드인지 알려줍니다. 로컬 노드를 모르면 항상 false 입니다.
+func (s *SelfTracker) IsSelf(nodeID string) bool {
+	if nodeID == "" {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	self := s.nodeIDLocked()
+	return self != "" && self == normalizeNodeID(nodeID)
+}
+
+func (s *SelfTracker) ObserveRound(tl RoundTimeline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	self := s.nodeIDLocked()
+	if self == "" {
+		return
+	}
+	if tl.Leader == self && tl.ProposalAt != 0 {
+		s.metrics.RoundsLed++
+		if tl.ProposalToQCMs != 0 {
+			s.proposalToQC.Add(tl.ProposalToQCMs)
+		}
+	}
+	for _, vote := range tl.VoteLatencies {
+		if vote.Voter == self {
+			s.metrics.VotesSent++
+			s.voteLatency.Add(vote.LatencyMs)
+			break
+		}
+	}
+}
+
+func (s *SelfTracker) ObserveProposal(header *common.ConsensusBlockHeader, meta model.MessageMeta) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	self := s.nodeIDLocked()
+	if self == "" || hex.EncodeToString(header.Author) != self {
+		return
+	}
+	s.metrics.ProposalsSent++
+	if proposedMs := float64(header.TimestampNS.Uint64()) / 1e6; proposedMs != 0 {
+		s.proposalEgress.Add(float64(meta.CaptureTime.UnixMicro())/1000.0 - proposedMs)
+	}
+}
+
+// ObserveQC 는 QC 서명자 비트맵에 로컬 노드가 들어 있는지 셉니다.
+// 비트 순서는 해당 에포크 검증자 집합의 순서이며, 바이트 안에서는 낮은 비트부터입니다.
+func (s *SelfTracker) ObserveQC(qc *common.QuorumCertificate) {
+	if qc == nil {
+		return
+	}
+
+	s.mu.Lock()
+	self := s.nodeIDLocked()
+	if self == "" || qc.Info.Round <= s.lastQCRound {
+		s.mu.Unlock()
+		return
+	}
+	s.lastQCRound = qc.Info.Round
+	s.mu.Unlock()
+
+	validators, ok := s.store.Set(qc.Info.Epoch)
+	if !ok {
+		return
+	}
+	index := -1
+	for i, v := range validators {
+		if normalizeNodeID(v.NodeID) == self {
+			index = i
+			break
+		}
+	}
+	var sigs common.SignatureCollection
+	if err := rlp.DecodeBytes(qc.Signatures, &sigs); err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics.QCsSeen++
+	if index >= 0 && index/8 < len(sigs.Signers.Buf) && sigs.Signers.Buf[index/8]&(1<<(index%8)) != 0 {
+		s.metrics.QCsSigned++
+	}
+}
+
+func (s *SelfTracker) snapshotLocked() SelfMetrics {
+	metrics := s.metrics
+	metrics.NodeID = s.nodeID
+	metrics.BLSPubkey = s.identity.BLSPubkey
+	metrics.VoteLatencyP50Ms = s.voteLatency.Percentile(50)
+	metrics.VoteLatencyP90Ms = s.voteLatency.Percentile(90)
+	metrics.ProposalEgressP50Ms = s.proposalEgress.Percentile(50)
+	metrics.ProposalToQCP50Ms = s.proposalToQC.Percentile(50)
+	if metrics.QCsSeen > 0 {
+		metrics.QCShare = float64(metrics.QCsSigned) / float64(metrics.QCsSeen)
+	}
+	return metrics
+}
+
+func (s *SelfTracker) Snapshot() (SelfMetrics, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.nodeIDLocked() == "" {
+		return SelfMetrics{}, false
+	}
+	return s.snapshotLocked(), true
+}
+
+func (s *SelfTracker) Sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nodeIDLocked() == "" || now.Sub(s.lastPublish) < selfMetricsPublishEvery {
+		return
+	}
+	s.lastPublish = now
+	publisher.Publish(util.SELF_METRICS_EVENT, s.snapshotLocked())
+}
@@ -0,0 +1,332 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"log"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/proposal"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/timeout"
+	"monad-flow/publisher"
+	"monad-flow/util"
+
+	"github.com/joho/godotenv"
+)
+
+const (
+	defaultStallNoQCMs        = 2000
+	defaultStallTimeoutRounds = 3
+	defaultStallSeqNumRounds  = 5
+
+	stallContextSize   = 5
+	stallRecentLeaders = 5
+)
+
+const (
+	StallKindNoQC          = "no_qc"
+	StallKindTimeoutRounds = "timeout_rounds"
+	StallKindSeqNumStalled = "seqnum_stalled"
+	StallKindRecovered     = "recovered"
+)
+
+type StallThresholds struct {
+	NoQCMs        int64 `json:"noQcMs"`
+	TimeoutRounds int   `json:"timeoutRounds"`
+	SeqNumRounds  int   `json:"seqNumRounds"`
+}
+
+type StallProposal struct {
+	Round   util.Round  `json:"round"`
+	SeqNum  util.SeqNum `json:"seqNum"`
+	Author  string      `json:"author"`
+	BlockID string      `json:"blockId"`
+	At      int64       `json:"at"`
+}
+
+type StallTimeout struct {
+	Round        util.Round `json:"round"`
+	Sender       string     `json:"sender"`
+	HighQCRound  util.Round `json:"highQcRound"`
+	HighTipRound util.Round `json:"highTipRound"`
+	At           int64      `json:"at"`
+}
+
+type StallLeader struct {
+	Round  util.Round `json:"round"`
+	NodeID string     `json:"nodeId"`
+	// 최근 제안 목록에 이 라운드의 제안이 있는지
+	Proposed bool `json:"proposed"`
+}
+
+type StallContext struct {
+	RecentProposals []StallProposal `json:"recentProposals"`
+	RecentTimeouts  []StallTimeout  `json:"recentTimeouts"`
+	Leaders         []StallLeader   `json:"leaders"`
+}
+
+type ChainStall struct {
+	Kind              string          `json:"kind"`
+	DetectedAt        int64           `json:"detectedAt"`
+	Round             util.Round      `json:"round"`
+	Epoch             util.Epoch      `json:"epoch"`
+	SeqNum            util.SeqNum     `json:"seqNum"`
+	LastQCRound       util.Round      `json:"lastQcRound"`
+	SinceLastQCMs     float64         `json:"sinceLastQcMs"`
+	ConsecutiveTCs    int             `json:"consecutiveTcs"`
+	RoundsSinceSeqNum util.Round      `json:"roundsSinceSeqNum"`
+	Thresholds        StallThresholds `json:"thresholds"`
+	Context           StallContext    `json:"context"`
+}
+
+// StallDetector 는 라운드와 QC, SeqNum 진행을 지켜보다가 임계값을 넘으면 당시 상황과 함께 이벤트를 냅니다.
+type StallDetector struct {
+	mu         sync.Mutex
+	schedule   *LeaderSchedule
+	thresholds StallThresholds
+
+	round          util.Round
+	epoch          util.Epoch
+	lastQCRound    util.Round
+	lastQCAt       time.Time
+	consecutiveTCs int
+	lastTCRound    util.Round
+	seqNum         util.SeqNum
+	seqNumRound    util.Round
+
+	noQCActive   bool
+	tcActive     bool
+	seqNumActive bool
+
+	proposals []StallProposal
+	timeouts  []StallTimeout
+}
+
+func NewStallDetector(schedule *LeaderSchedule) *StallDetector {
+	return &StallDetector{
+		schedule:   schedule,
+		thresholds: getStallThresholds(),
+	}
+}
+
+func getStallThresholds() StallThresholds {
+	godotenv.Load()
+	return StallThresholds{
+		NoQCMs:        int64(envInt("STALL_NO_QC_MS", defaultStallNoQCMs)),
+		TimeoutRounds: envInt("STALL_TIMEOUT_ROUNDS", defaultStallTimeoutRounds),
+		SeqNumRounds:  envInt("STALL_SEQNUM_ROUNDS", defaultStallSeqNumRounds),
+	}
+}
+
+func envInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Printf("Invalid %s value: %s, using default %d", key, value, fallback)
+		return fallback
+	}
+	return n
+}
+
+func (d *StallDetector) observeRoundLocked(round util.Round, epoch util.Epoch) {
+	if round > d.round {
+		d.round = round
+	}
+	if epoch > d.epoch {
+		d.epoch = epoch
+	}
+}
+
+func (d *StallDetector) ObserveProposal(p *proposal.ProposalMessage, meta model.MessageMeta) {
+	header := p.Tip.BlockHeader
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.observeRoundLocked(p.ProposalRound, p.ProposalEpoch)
+	d.proposals = appendBounded(d.proposals, StallProposal{
+		Round:   p.ProposalRound,
+		SeqNum:  header.SeqNum,
+		Author:  hex.EncodeToString(header.Author),
+		BlockID: p.BlockID.Hex(),
+		At:      meta.CaptureTime.UnixMicro(),
+	})
+
+	if header.SeqNum > d.seqNum {
+		d.seqNum = header.SeqNum
+		d.seqNumRound = p.ProposalRound
+		d.seqNumActive = false
+	} else {
+		d.checkSeqNumLocked(meta.CaptureTime)
+	}
+
+	d.observeQCLocked(header.QC.Info.Round, meta.CaptureTime)
+}
+
+func (d *StallDetector) ObserveTimeout(msg *timeout.TimeoutMessage, meta model.MessageMeta) {
+	if msg.TMInfo == nil {
+		return
+	}
+	sender := meta.Author
+	if sender == "" {
+		sender = meta.SrcIP
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.observeRoundLocked(msg.TMInfo.Round, msg.TMInfo.Epoch)
+	d.timeouts = appendBounded(d.timeouts, StallTimeout{
+		Round:        msg.TMInfo.Round,
+		Sender:       sender,
+		HighQCRound:  msg.TMInfo.HighQCRound,
+		HighTipRound: msg.TMInfo.HighTipRound,
+		At:           meta.CaptureTime.UnixMicro(),
+	})
+	d.checkNoQCLocked(meta.CaptureTime)
+	d.checkSeqNumLocked(meta.CaptureTime)
+}
+
+func (d *StallDetector) ObserveQC(qc *common.QuorumCertificate, now time.Time) {
+	if qc == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.observeQCLocked(qc.Info.Round, now)
+}
+
+func (d *StallDetector) observeQCLocked(round util.Round, now time.Time) {
+	if round <= d.lastQCRound {
+		d.checkNoQCLocked(now)
+		return
+	}
+	d.lastQCRound = round
+	d.lastQCAt = now
+	d.consecutiveTCs = 0
+	d.tcActive = false
+	if d.noQCActive {
+		d.noQCActive = false
+		d.fireLocked(StallKindRecovered, now)
+	}
+}
+
+// ObserveTC 는 QC 없이 TC 로만 넘어간 라운드가 연속으로 몇 개인지 셉니다.
+func (d *StallDetector) ObserveTC(tc *common.TimeoutCertificate, now time.Time) {
+	if tc == nil {
+		return
+	}
+	round := tc.Round
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.observeRoundLocked(round+1, tc.Epoch)
+	d.checkSeqNumLocked(now)
+	if round <= d.lastTCRound {
+		return
+	}
+	d.lastTCRound = round
+	if round <= d.lastQCRound {
+		return
+	}
+	d.consecutiveTCs++
+	if !d.tcActive && d.consecutiveTCs >= d.thresholds.TimeoutRounds {
+		d.tcActive = true
+		d.fireLocked(StallKindTimeoutRounds, now)
+	}
+}
+
+func (d *StallDetector) checkNoQCLocked(now time.Time) {
+	if d.noQCActive || d.lastQCAt.IsZero() {
+		return
+	}
+	if now.Sub(d.lastQCAt) >= time.Duration(d.thresholds.NoQCMs)*time.Millisecond {
+		d.noQCActive = true
+		d.fireLocked(StallKindNoQC, now)
+	}
+}
+
+// checkSeqNumLocked 는 제안이 없거나 TC 로만 넘어가는 라운드도 포함해, 마지막으로 SeqNum 이
+// 늘어난 뒤 라운드가 임계값 이상 진행되었는지 봅니다.
+func (d *StallDetector) checkSeqNumLocked(now time.Time) {
+	if d.seqNumActive || d.seqNumRound == 0 {
+		return
+	}
+	if d.round >= d.seqNumRound+util.Round(d.thresholds.SeqNumRounds) {
+		d.seqNumActive = true
+		d.fireLocked(StallKindSeqNumStalled, now)
+	}
+}
+
+func (d *StallDetector) Sweep(now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.checkNoQCLocked(now)
+}
+
+func (d *StallDetector) fireLocked(kind string, now time.Time) {
+	stall := ChainStall{
+		Kind:           kind,
+		DetectedAt:     now.UnixMicro(),
+		Round:          d.round,
+		Epoch:          d.epoch,
+		SeqNum:         d.seqNum,
+		LastQCRound:    d.lastQCRound,
+		ConsecutiveTCs: d.consecutiveTCs,
+		Thresholds:     d.thresholds,
+		Context: StallContext{
+			RecentProposals: append([]StallProposal(nil), d.proposals...),
+			RecentTimeouts:  append([]StallTimeout(nil), d.timeouts...),
+			Leaders:         d.leadersLocked(),
+		},
+	}
+	if !d.lastQCAt.IsZero() {
+		stall.SinceLastQCMs = float64(now.Sub(d.lastQCAt).Microseconds()) / 1000.0
+	}
+	if d.seqNumRound != 0 && d.round > d.seqNumRound {
+		stall.RoundsSinceSeqNum = d.round - d.seqNumRound
+	}
+
+	log.Printf("[Stall] %s at round %d (seqNum %d, last QC round %d)", kind, d.round, d.seqNum, d.lastQCRound)
+	publisher.Publish(util.CHAIN_STALL_EVENT, stall)
+}
+
+// leadersLocked 는 현재 라운드까지 최근 몇 라운드의 예상 리더와, 그 리더의 제안을 봤는지입니다.
+func (d *StallDetector) leadersLocked() []StallLeader {
+	from := util.Round(1)
+	if d.round > stallRecentLeaders {
+		from = d.round - stallRecentLeaders + 1
+	}
+	leaders := make([]StallLeader, 0, stallRecentLeaders)
+	for r := from; r <= d.round; r++ {
+		leader, ok := d.schedule.Leader(d.epoch, r)
+		if !ok {
+			continue
+		}
+		entry := StallLeader{Round: r, NodeID: leader.NodeID}
+		for _, p := range d.proposals {
+			if p.Round == r {
+				entry.Proposed = true
+				break
+			}
+		}
+		leaders = append(leaders, entry)
+	}
+	return leaders
+}
+
+func appendBounded[T any](items []T, item T) []T {
+	items = append(items, item)
+	if len(items) > stallContextSize {
+		items = items[len(items)-stallContextSize:]
+	}
+	return items
+}
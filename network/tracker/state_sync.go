@@ -0,0 +1,260 @@
+package tracker
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/state_sync"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	stateSyncIdleTimeout = 60 * time.Second
+	maxStateSyncSessions = 64
+
+	StateSyncCompleted = "completed"
+	StateSyncIdle      = "idle"
+	StateSyncEvicted   = "evicted"
+)
+
+type StateSyncPrefixStats struct {
+	Prefix      uint64 `json:"prefix"`
+	PrefixBytes uint8  `json:"prefixBytes"`
+	From        uint64 `json:"from"`
+	Until       uint64 `json:"until"`
+	Requests    int    `json:"requests"`
+	Responses   int    `json:"responses"`
+	Upserts     int    `json:"upserts"`
+	Bytes       int    `json:"bytes"`
+	Gaps        int    `json:"gaps"`
+	Duplicates  int    `json:"duplicates"`
+	Complete    bool   `json:"complete"`
+
+	seenIndices map[uint32]bool
+	maxIndex    uint32
+}
+
+type StateSyncSessionSummary struct {
+	Client                string                  `json:"client"`
+	Server                string                  `json:"server"`
+	Target                uint64                  `json:"target"`
+	SessionID             uint64                  `json:"sessionId,omitempty"`
+	Status                string                  `json:"status"`
+	StartedAt             int64                   `json:"startedAt"`
+	DurationMs            float64                 `json:"durationMs"`
+	TotalUpserts          int                     `json:"totalUpserts"`
+	TotalBytes            int                     `json:"totalBytes"`
+	ThroughputBytesPerSec float64                 `json:"throughputBytesPerSec"`
+	Retries               int                     `json:"retries"`
+	Gaps                  int                     `json:"gaps"`
+	BadVersions           int                     `json:"badVersions"`
+	Prefixes              []*StateSyncPrefixStats `json:"prefixes"`
+}
+
+// stateSyncSession 은 한 클라이언트가 한 서버에서 특정 target 을 받아 가는 흐름입니다.
+// Completion 은 서버별 nonce 로 오므로 서버마다 따로 끝납니다.
+type stateSyncSession struct {
+	client      string
+	server      string
+	target      uint64
+	nonces      map[uint64]bool
+	prefixes    map[string]*StateSyncPrefixStats
+	badVersions int
+	startedAt   time.Time
+	lastSeen    time.Time
+}
+
+type StateSyncTracker struct {
+	mu       sync.Mutex
+	sessions map[string]*stateSyncSession
+}
+
+func NewStateSyncTracker() *StateSyncTracker {
+	return &StateSyncTracker{
+		sessions: make(map[string]*stateSyncSession),
+	}
+}
+
+func sessionKey(client, server string, target uint64) string {
+	return fmt.Sprintf("%s>%s:%d", client, server, target)
+}
+
+func prefixKey(req *state_sync.StateSyncRequest) string {
+	return fmt.Sprintf("%d/%d:%d-%d", req.Prefix, req.PrefixBytes, req.From, req.Until)
+}
+
+func (t *StateSyncTracker) Observe(msg *state_sync.StateSyncNetworkMessage, meta model.MessageMeta) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch msg.TypeID {
+	case util.TypeRequest:
+		session := t.sessionLocked(meta.SrcIP, meta.DstIP, msg.Request.Target, meta.CaptureTime)
+		session.prefixLocked(&msg.Request).Requests++
+
+	case util.TypeResponse:
+		resp := &msg.Response
+		session := t.sessionLocked(meta.DstIP, meta.SrcIP, resp.Request.Target, meta.CaptureTime)
+		session.nonces[resp.Nonce] = true
+
+		prefix := session.prefixLocked(&resp.Request)
+		prefix.Responses++
+		prefix.Upserts += len(resp.Response)
+		for _, upsert := range resp.Response {
+			prefix.Bytes += len(upsert.Data)
+		}
+		if prefix.seenIndices[resp.ResponseIndex] {
+			prefix.Duplicates++
+		}
+		prefix.seenIndices[resp.ResponseIndex] = true
+		if resp.ResponseIndex > prefix.maxIndex {
+			prefix.maxIndex = resp.ResponseIndex
+		}
+		// ResponseN 이 채워진 응답이 해당 요청의 마지막 응답입니다.
+		if resp.ResponseN != 0 {
+			prefix.Complete = true
+		}
+		prefix.Gaps = int(prefix.maxIndex) + 1 - len(prefix.seenIndices)
+
+	case util.TypeBadVersion:
+		// BadVersion 은 서버가 클라이언트의 요청을 거절한 것이므로 DstIP 가 클라이언트입니다.
+		for _, session := range t.sessions {
+			if session.client == meta.DstIP && session.server == meta.SrcIP {
+				session.badVersions++
+				session.lastSeen = meta.CaptureTime
+			}
+		}
+
+	case util.TypeCompletion:
+		if session, key := t.findCompletedLocked(msg.Completion.Value, meta); session != nil {
+			delete(t.sessions, key)
+			publisher.Publish(util.STATE_SYNC_SESSION_EVENT, session.summary(StateSyncCompleted, msg.Completion.Value, meta.CaptureTime))
+		}
+	}
+}
+
+func (t *StateSyncTracker) sessionLocked(client, server string, target uint64, now time.Time) *stateSyncSession {
+	key := sessionKey(client, server, target)
+	session, ok := t.sessions[key]
+	if !ok {
+		if len(t.sessions) >= maxStateSyncSessions {
+			t.evictIdlestLocked()
+		}
+		session = &stateSyncSession{
+			client:    client,
+			server:    server,
+			target:    target,
+			nonces:    make(map[uint64]bool),
+			prefixes:  make(map[string]*StateSyncPrefixStats),
+			startedAt: now,
+		}
+		t.sessions[key] = session
+	}
+	session.lastSeen = now
+	return session
+}
+
+// evictIdlestLocked 는 가장 오래 활동이 없던 세션을 evicted 상태로 내보내고 자리를 비웁니다.
+func (t *StateSyncTracker) evictIdlestLocked() {
+	var idlestKey string
+	var idlest *stateSyncSession
+	for key, session := range t.sessions {
+		if idlest == nil || session.lastSeen.Before(idlest.lastSeen) {
+			idlestKey, idlest = key, session
+		}
+	}
+	if idlest == nil {
+		return
+	}
+	delete(t.sessions, idlestKey)
+	publisher.Publish(util.STATE_SYNC_SESSION_EVENT, idlest.summary(StateSyncEvicted, 0, idlest.lastSeen))
+}
+
+func (s *stateSyncSession) prefixLocked(req *state_sync.StateSyncRequest) *StateSyncPrefixStats {
+	key := prefixKey(req)
+	prefix, ok := s.prefixes[key]
+	if !ok {
+		prefix = &StateSyncPrefixStats{
+			Prefix:      req.Prefix,
+			PrefixBytes: req.PrefixBytes,
+			From:        req.From,
+			Until:       req.Until,
+			seenIndices: make(map[uint32]bool),
+		}
+		s.prefixes[key] = prefix
+	}
+	return prefix
+}
+
+// findCompletedLocked 는 Completion 의 SessionId 와 같은 nonce 를 받은 세션을 우선 찾고,
+// 없으면 Completion 을 주고받은 클라이언트-서버 쌍에서 가장 최근에 활동한 세션을 돌려줍니다.
+func (t *StateSyncTracker) findCompletedLocked(sessionID uint64, meta model.MessageMeta) (*stateSyncSession, string) {
+	var fallback *stateSyncSession
+	var fallbackKey string
+	for key, session := range t.sessions {
+		if session.nonces[sessionID] {
+			return session, key
+		}
+		pair := (session.client == meta.SrcIP && session.server == meta.DstIP) ||
+			(session.client == meta.DstIP && session.server == meta.SrcIP)
+		if !pair {
+			continue
+		}
+		if fallback == nil || session.lastSeen.After(fallback.lastSeen) {
+			fallback, fallbackKey = session, key
+		}
+	}
+	return fallback, fallbackKey
+}
+
+func (s *stateSyncSession) summary(status string, sessionID uint64, now time.Time) StateSyncSessionSummary {
+	summary := StateSyncSessionSummary{
+		Client:      s.client,
+		Server:      s.server,
+		Target:      s.target,
+		SessionID:   sessionID,
+		Status:      status,
+		StartedAt:   s.startedAt.UnixMicro(),
+		DurationMs:  float64(now.Sub(s.startedAt).Microseconds()) / 1000.0,
+		BadVersions: s.badVersions,
+	}
+
+	for _, prefix := range s.prefixes {
+		summary.Prefixes = append(summary.Prefixes, prefix)
+		summary.TotalUpserts += prefix.Upserts
+		summary.TotalBytes += prefix.Bytes
+		summary.Gaps += prefix.Gaps
+		if prefix.Requests > 1 {
+			summary.Retries += prefix.Requests - 1
+		}
+	}
+	sort.Slice(summary.Prefixes, func(i, j int) bool {
+		if summary.Prefixes[i].Prefix != summary.Prefixes[j].Prefix {
+			return summary.Prefixes[i].Prefix < summary.Prefixes[j].Prefix
+		}
+		return summary.Prefixes[i].From < summary.Prefixes[j].From
+	})
+
+	if seconds := now.Sub(s.startedAt).Seconds(); seconds > 0 {
+		summary.ThroughputBytesPerSec = float64(summary.TotalBytes) / seconds
+	}
+	return summary
+}
+
+// Sweep 은 Completion 없이 멈춘 세션을 idle 상태로 내보내고 정리합니다.
+func (t *StateSyncTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for key, session := range t.sessions {
+		if now.Sub(session.lastSeen) < stateSyncIdleTimeout {
+			continue
+		}
+		delete(t.sessions, key)
+		publisher.Publish(util.STATE_SYNC_SESSION_EVENT, session.summary(StateSyncIdle, 0, session.lastSeen))
+	}
+}
@@ -0,0 +1,287 @@
+package tracker
+
+import (
+	"math/big"
+	"math/bits"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/common"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/timeout"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	// TC 를 보지 못한 라운드는 마지막 타임아웃 이후 이만큼 지나면 TC 없이 보고합니다.
+	timeoutAnalysisIdle = 10 * time.Second
+	// TC 없이 보고한 라운드는 늦게 도착하는 TC 로 다시 보고할 수 있도록 이만큼 더 보관합니다.
+	timeoutLateTCWindow = time.Minute
+	maxTimeoutRounds    = 256
+)
+
+const (
+	ExtendKindTip = "tip"
+	ExtendKindQC  = "qc"
+)
+
+type TimeoutVote struct {
+	Sender       string     `json:"sender"`
+	At           int64      `json:"at"`
+	HighQCRound  util.Round `json:"highQcRound"`
+	HighTipRound util.Round `json:"highTipRound"`
+	ExtendKind   string     `json:"extendKind,omitempty"`
+	// 이 타임아웃까지 누적된 타임아웃 지분 비율 (검증자 집합을 모르면 0)
+	StakeFraction float64 `json:"stakeFraction"`
+}
+
+type RoundCount struct {
+	Round util.Round `json:"round"`
+	Count int        `json:"count"`
+}
+
+type TCTipRound struct {
+	HighQCRound  util.Round `json:"highQcRound"`
+	HighTipRound util.Round `json:"highTipRound"`
+	Signers      int        `json:"signers"`
+}
+
+type TimeoutAnalysis struct {
+	Round              util.Round    `json:"round"`
+	Epoch              util.Epoch    `json:"epoch"`
+	Timeouts           []TimeoutVote `json:"timeouts"`
+	HighQCRounds       []RoundCount  `json:"highQcRounds"`
+	HighTipRounds      []RoundCount  `json:"highTipRounds"`
+	FinalStake         float64       `json:"finalStakeFraction"`
+	TCAt               int64         `json:"tcAt,omitempty"`
+	TCExtendKind       string        `json:"tcExtendKind,omitempty"`
+	TCExtendRound      util.Round    `json:"tcExtendRound,omitempty"`
+	TCTipRounds        []TCTipRound  `json:"tcTipRounds,omitempty"`
+	FirstTimeoutToTCMs float64       `json:"firstTimeoutToTcMs,omitempty"`
+	// TC 없이 먼저 보고된 라운드를 늦게 도착한 TC 로 다시 보고한 경우 true
+	Revised bool `json:"revised,omitempty"`
+}
+
+type timeoutRoundState struct {
+	analysis     TimeoutAnalysis
+	senders      map[string]bool
+	stake        *big.Int
+	lastActivity time.Time
+	// TC 없이 보고한 시각 (아직 보고하지 않았으면 zero)
+	reportedAt time.Time
+}
+
+// TimeoutDiagnostics 는 라운드별 타임아웃 메시지와 TC 를 모아 라운드가 실패한 이유를 정리합니다.
+type TimeoutDiagnostics struct {
+	mu       sync.Mutex
+	store    *ValidatorStore
+	rounds   map[util.Round]*timeoutRoundState
+	reported map[util.Round]bool
+}
+
+func NewTimeoutDiagnostics(store *ValidatorStore) *TimeoutDiagnostics {
+	return &TimeoutDiagnostics{
+		store:    store,
+		rounds:   make(map[util.Round]*timeoutRoundState),
+		reported: make(map[util.Round]bool),
+	}
+}
+
+func (d *TimeoutDiagnostics) roundLocked(round util.Round, epoch util.Epoch, now time.Time) *timeoutRoundState {
+	if d.reported[round] {
+		return nil
+	}
+	state, ok := d.rounds[round]
+	if !ok {
+		if len(d.rounds) >= maxTimeoutRounds {
+			d.evictOldestLocked()
+		}
+		state = &timeoutRoundState{
+			analysis: TimeoutAnalysis{Round: round, Epoch: epoch},
+			senders:  make(map[string]bool),
+			stake:    new(big.Int),
+		}
+		d.rounds[round] = state
+	}
+	state.lastActivity = now
+	return state
+}
+
+func (d *TimeoutDiagnostics) ObserveTimeout(msg *timeout.TimeoutMessage, meta model.MessageMeta) {
+	if msg.TMInfo == nil {
+		return
+	}
+	info := msg.TMInfo
+
+	sender := meta.Author
+	if sender == "" {
+		sender = meta.SrcIP
+	}
+
+	stake, total, _ := d.store.Stake(info.Epoch, sender)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state := d.roundLocked(info.Round, info.Epoch, meta.CaptureTime)
+	if state == nil || state.senders[sender] {
+		return
+	}
+	state.senders[sender] = true
+
+	vote := TimeoutVote{
+		Sender:       sender,
+		At:           meta.CaptureTime.UnixMicro(),
+		HighQCRound:  info.HighQCRound,
+		HighTipRound: info.HighTipRound,
+	}
+	vote.ExtendKind, _ = highExtendRound(&msg.HighExtend)
+	if stake != nil {
+		state.stake.Add(state.stake, stake)
+		vote.StakeFraction = stakeFraction(state.stake, total)
+	}
+	state.analysis.Timeouts = append(state.analysis.Timeouts, vote)
+}
+
+func (d *TimeoutDiagnostics) ObserveTC(tc *common.TimeoutCertificate, meta model.MessageMeta) {
+	if tc == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state := d.roundLocked(tc.Round, tc.Epoch, meta.CaptureTime)
+	if state == nil {
+		return
+	}
+
+	analysis := &state.analysis
+	analysis.Revised = !state.reportedAt.IsZero()
+	analysis.TCAt = meta.CaptureTime.UnixMicro()
+	analysis.TCExtendKind, analysis.TCExtendRound = highExtendRound(tc.HighExtend)
+	for _, tuple := range tc.TipRounds {
+		analysis.TCTipRounds = append(analysis.TCTipRounds, TCTipRound{
+			HighQCRound:  tuple.HighQCRound,
+			HighTipRound: tuple.HighTipRound,
+			Signers:      countSigners(tuple.Sigs.Signers),
+		})
+	}
+	d.publishLocked(state)
+	d.finishLocked(tc.Round)
+}
+
+// evictOldestLocked 는 가장 오래된 라운드를 (아직 보고하지 않았다면 보고한 뒤) 정리합니다.
+func (d *TimeoutDiagnostics) evictOldestLocked() {
+	var oldest *timeoutRoundState
+	for _, state := range d.rounds {
+		if oldest == nil || state.analysis.Round < oldest.analysis.Round {
+			oldest = state
+		}
+	}
+	if oldest == nil {
+		return
+	}
+	if oldest.reportedAt.IsZero() {
+		d.publishLocked(oldest)
+	}
+	d.finishLocked(oldest.analysis.Round)
+}
+
+// finishLocked 는 라운드를 더 이상 갱신하지 않도록 닫습니다.
+func (d *TimeoutDiagnostics) finishLocked(round util.Round) {
+	delete(d.rounds, round)
+	d.reported[round] = true
+	if len(d.reported) > maxTimeoutRounds {
+		d.pruneReportedLocked(round)
+	}
+}
+
+func (d *TimeoutDiagnostics) publishLocked(state *timeoutRoundState) {
+	analysis := state.analysis
+	analysis.HighQCRounds = countRounds(analysis.Timeouts, func(v TimeoutVote) util.Round { return v.HighQCRound })
+	analysis.HighTipRounds = countRounds(analysis.Timeouts, func(v TimeoutVote) util.Round { return v.HighTipRound })
+	if n := len(analysis.Timeouts); n > 0 {
+		analysis.FinalStake = analysis.Timeouts[n-1].StakeFraction
+		if analysis.TCAt != 0 {
+			analysis.FirstTimeoutToTCMs = float64(analysis.TCAt-analysis.Timeouts[0].At) / 1000.0
+		}
+	}
+	publisher.Publish(util.TIMEOUT_ANALYSIS_EVENT, analysis)
+}
+
+func (d *TimeoutDiagnostics) pruneReportedLocked(latest util.Round) {
+	for round := range d.reported {
+		if round+maxTimeoutRounds < latest {
+			delete(d.reported, round)
+		}
+	}
+}
+
+// Sweep 은 TC 가 관측되지 않은 채 조용해진 라운드를 보고하고,
+// 보고 후에도 TC 가 오지 않은 라운드를 정리합니다.
+func (d *TimeoutDiagnostics) Sweep(now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for round, state := range d.rounds {
+		if state.reportedAt.IsZero() {
+			if now.Sub(state.lastActivity) >= timeoutAnalysisIdle {
+				d.publishLocked(state)
+				state.reportedAt = now
+			}
+		} else if now.Sub(state.reportedAt) >= timeoutLateTCWindow {
+			d.finishLocked(round)
+		}
+	}
+}
+
+func stakeFraction(stake, total *big.Int) float64 {
+	if total == nil || total.Sign() == 0 {
+		return 0
+	}
+	f, _ := new(big.Rat).SetFrac(stake, total).Float64()
+	return f
+}
+
+func highExtendRound(w *common.HighExtendWrapper) (string, util.Round) {
+	if w == nil {
+		return "", 0
+	}
+	switch ext := w.Extend.(type) {
+	case *common.HighExtendTip:
+		if ext.Tip != nil && ext.Tip.BlockHeader != nil {
+			return ExtendKindTip, ext.Tip.BlockHeader.BlockRound
+		}
+		return ExtendKindTip, 0
+	case *common.HighExtendQc:
+		if ext.QC != nil {
+			return ExtendKindQC, ext.QC.Info.Round
+		}
+		return ExtendKindQC, 0
+	}
+	return "", 0
+}
+
+func countSigners(signers common.SignerMap) int {
+	count := 0
+	for _, b := range signers.Buf {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
+
+func countRounds(votes []TimeoutVote, roundOf func(TimeoutVote) util.Round) []RoundCount {
+	counts := make(map[util.Round]int)
+	for _, v := range votes {
+		counts[roundOf(v)]++
+	}
+	result := make([]RoundCount, 0, len(counts))
+	for round, count := range counts {
+		result = append(result, RoundCount{Round: round, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Round < result[j].Round })
+	return result
+}
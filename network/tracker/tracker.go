@@ -0,0 +1,173 @@
+package tracker
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/fullnode_group"
+	"monad-flow/model/message/outbound_router/monad"
+	"monad-flow/model/message/outbound_router/monad/block_sync_request"
+	"monad-flow/model/message/outbound_router/monad/block_sync_response"
+	"monad-flow/model/message/outbound_router/monad/consensus"
+	"monad-flow/model/message/outbound_router/monad/state_sync"
+	"monad-flow/model/message/outbound_router/peer_discovery"
+	"monad-flow/util"
+)
+
+const sweepInterval = 1 * time.Second
+
+var (
+	blockSync  = NewBlockSyncTracker()
+	stateSync  = NewStateSyncTracker()
+	peerTable  = NewPeerTable()
+	groups     = NewFullNodeGroupTracker()
+	rounds     = NewRoundTimelineTracker()
+	validators = NewValidatorStore()
+	leaders    = NewLeaderSchedule(validators)
+	scores     = NewLeaderScoreTracker(leaders)
+	votes      = NewVoteLatencyTracker()
+	execDelay  = NewExecutionDelayTracker()
+	equivocs   = NewEquivocationDetector()
+	blockTree  = NewBlockTree()
+	tcDiag     = NewTimeoutDiagnostics(validators)
+	inferred   = NewValidatorInference(validators)
+	propagate  = NewPropagationTracker()
+	blockStats = NewBlockMetricsTracker()
+	execFork   = NewExecutionDivergenceDetector()
+	localNode  = NewSelfTracker(validators)
+	relays     = NewRelayTracker()
+	stalls     = NewStallDetector(leaders)
+	traffic    = NewTrafficAccounting()
+	matrix     = NewTrafficMatrixTracker(validators)
+)
+
+func init() {
+	rounds.OnFinalize(scores.ObserveRound)
+	rounds.OnFinalize(votes.ObserveRound)
+	rounds.OnFinalize(localNode.ObserveRound)
+}
+
+// Start 는 타임아웃 처리처럼 메시지 도착과 무관하게 돌아야 하는 주기 작업을 실행합니다.
+func Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		log.Println("[Tracker] Started.")
+
+		ticker := time.NewTicker(sweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				log.Println("[Tracker] Shutting down.")
+				return
+			case now := <-ticker.C:
+				validators.Sweep(now)
+				blockSync.Sweep(now)
+				stateSync.Sweep(now)
+				rounds.Sweep(now)
+				scores.Sweep(now)
+				votes.Sweep(now)
+				tcDiag.Sweep(now)
+				inferred.Sweep(now)
+				propagate.Sweep(now)
+				localNode.Sweep(now)
+				relays.Sweep(now)
+				stalls.Sweep(now)
+				traffic.Sweep(now)
+				matrix.Sweep(now)
+			}
+		}
+	}()
+}
+
+// Observe 는 디코딩이 끝난 메시지를 각 트래커로 전달합니다.
+func Observe(combined *model.OutboundRouterCombined, meta model.MessageMeta) {
+	traffic.ObserveMessage(combined, meta)
+	if msg, ok := combined.PeerDiscovery.(*peer_discovery.PeerDiscoveryMessage); ok {
+		peerTable.ObservePeerDiscovery(msg, meta)
+	}
+	if msg, ok := combined.FullNodesGroup.(*fullnode_group.FullNodesGroupMessage); ok {
+		peerTable.ObserveFullNodesGroup(msg, meta)
+		groups.Observe(msg, meta)
+	}
+	if msg, ok := combined.AppMessage.(*monad.MonadMessage); ok {
+		observeMonadMessage(msg, meta)
+	}
+}
+
+// ObserveChunk 는 디코딩 여부와 관계없이 수신된 모든 청크를 전달받습니다. size 는 청크의 실제 UDP 페이로드 길이입니다.
+func ObserveChunk(chunk *model.MonadChunkPacket, author string, size int, captureTime time.Time) {
+	peerTable.ObserveChunk(chunk, author, captureTime)
+	inferred.ObserveChunk(chunk, author, captureTime)
+	propagate.ObserveChunk(chunk, author, captureTime)
+	relays.ObserveChunk(chunk, author, captureTime)
+	traffic.ObserveChunk(chunk, author, size, captureTime)
+	matrix.ObserveChunk(chunk, author, size, captureTime)
+}
+
+// ObservePing 은 피어별 RTT 를 받아 시계 오프셋 추정에 씁니다.
+func ObservePing(ip string, rtt time.Duration) {
+	propagate.ObservePing(ip, rtt)
+}
+
+func Peers() []PeerEntry {
+	return peerTable.Snapshot()
+}
+
+func Peer(nodeID string) (PeerEntry, bool) {
+	return peerTable.Get(nodeID)
+}
+
+func FullNodeGroups() []FullNodeGroup {
+	return groups.Groups(0, false)
+}
+
+func ActiveFullNodeGroups(round util.Round) []FullNodeGroup {
+	return groups.Groups(round, true)
+}
+
+func ExpectedLeader(epoch util.Epoch, round util.Round) (util.Validator, bool) {
+	return leaders.Leader(epoch, round)
+}
+
+func LeaderScores(epoch util.Epoch) (LeaderScorecard, bool) {
+	return scores.Scorecard(epoch)
+}
+
+func VoterLatencies() []VoterLatencyStats {
+	return votes.Snapshot()
+}
+
+func InferredValidators(epoch util.Epoch) (InferredValidatorSet, bool) {
+	return inferred.Set(epoch)
+}
+
+func Propagation() []SenderPropagation {
+	return propagate.Snapshot()
+}
+
+func Self() (SelfMetrics, bool) {
+	return localNode.Snapshot()
+}
+
+func LastTrafficMatrix() (TrafficMatrix, bool) {
+	return matrix.Last()
+}
+
+func observeMonadMessage(msg *monad.MonadMessage, meta model.MessageMeta) {
+	switch payload := msg.Payload.(type) {
+	case *consensus.ConsensusMessage:
+		observeConsensusMessage(payload, meta)
+	case *block_sync_request.BlockSyncRequest:
+		blockSync.ObserveRequest(payload, meta)
+	case *block_sync_response.BlockSyncResponse:
+		blockSync.ObserveResponse(payload, meta)
+	case *state_sync.StateSyncNetworkMessage:
+		stateSync.Observe(payload, meta)
+	}
+}
@@ -0,0 +1,322 @@
+package tracker
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/model/message/outbound_router/fullnode_group"
+	"monad-flow/model/message/outbound_router/monad"
+	"monad-flow/model/message/outbound_router/monad/block_sync_request"
+	"monad-flow/model/message/outbound_router/monad/block_sync_response"
+	"monad-flow/model/message/outbound_router/monad/consensus"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/proposal"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/timeout"
+	"monad-flow/model/message/outbound_router/monad/consensus/protocol/vote"
+	"monad-flow/model/message/outbound_router/monad/forwarded_tx"
+	"monad-flow/model/message/outbound_router/monad/state_sync"
+	"monad-flow/model/message/outbound_router/peer_discovery"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	trafficReportInterval = 10 * time.Second
+	// 디코딩되지 않은 메시지의 청크는 이만큼 지나면 unknown 으로 셉니다.
+	trafficMessageTTL    = 5 * time.Second
+	trafficPeerIdleLimit = 10 * time.Minute
+)
+
+const (
+	TrafficProposal      = "proposal"
+	TrafficVote          = "vote"
+	TrafficTimeout       = "timeout"
+	TrafficConsensus     = "consensus_other"
+	TrafficBlockSync     = "block_sync"
+	TrafficStateSync     = "state_sync"
+	TrafficForwardedTx   = "forwarded_tx"
+	TrafficDiscovery     = "discovery"
+	TrafficFullNodeGroup = "fullnode_group"
+	TrafficUnknown       = "unknown"
+)
+
+type TrafficCounter struct {
+	Packets uint64 `json:"packets"`
+	Bytes   uint64 `json:"bytes"`
+}
+
+type TrafficDirection struct {
+	Packets uint64                    `json:"packets"`
+	Bytes   uint64                    `json:"bytes"`
+	ByType  map[string]TrafficCounter `json:"byType"`
+}
+
+type PeerTraffic struct {
+	IP     string           `json:"ip"`
+	NodeID string           `json:"nodeId,omitempty"`
+	In     TrafficDirection `json:"in"`
+	Out    TrafficDirection `json:"out"`
+}
+
+type TrafficStats struct {
+	IntervalStart int64            `json:"intervalStart"`
+	IntervalEnd   int64            `json:"intervalEnd"`
+	In            TrafficDirection `json:"in"`
+	Out           TrafficDirection `json:"out"`
+	Peers         []PeerTraffic    `json:"peers"`
+}
+
+type trafficKey struct {
+	ip     string
+	egress bool
+}
+
+type trafficMessage struct {
+	kind         string
+	pending      map[trafficKey]TrafficCounter
+	lastActivity time.Time
+}
+
+type peerTraffic struct {
+	nodeID   string
+	in       TrafficDirection
+	out      TrafficDirection
+	lastSeen time.Time
+}
+
+// TrafficAccounting 은 피어별, 방향별, 메시지 종류별 패킷 수와 바이트를 구간마다 집계합니다.
+// UDP 청크는 메시지가 디코딩되어 종류가 정해질 때까지 AppMessageHash 별로 모아 두었다가 반영합니다.
+type TrafficAccounting struct {
+	mu            sync.Mutex
+	messages      map[string]*trafficMessage
+	peers         map[string]*peerTraffic
+	intervalStart time.Time
+}
+
+func NewTrafficAccounting() *TrafficAccounting {
+	return &TrafficAccounting{
+		messages: make(map[string]*trafficMessage),
+		peers:    make(map[string]*peerTraffic),
+	}
+}
+
+func (t *TrafficAccounting) ObserveChunk(chunk *model.MonadChunkPacket, author string, chunkSize int, captureTime time.Time) {
+	src := chunk.Network.Ipv4.SrcIp
+	dst := chunk.Network.Ipv4.DstIp
+	key := trafficKey{ip: src}
+	if src != "" && util.IsLocalIP(src) {
+		key = trafficKey{ip: dst, egress: true}
+	}
+	if key.ip == "" {
+		return
+	}
+	size := uint64(chunkSize)
+	hash := fmt.Sprintf("0x%x", chunk.AppMessageHash)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	peer := t.peerLocked(key.ip, captureTime)
+	// 송신 청크의 author 는 우리 노드이고, 브로드캐스트 청크의 author 는 원 작성자라
+	// 보낸 피어가 아니므로 직접 받은 수신 청크에서만 NodeID 를 배웁니다.
+	if !key.egress && !chunk.Broadcast && !chunk.SecondaryBroadcast && author != "" {
+		peer.nodeID = author
+	}
+
+	msg, ok := t.messages[hash]
+	if !ok {
+		if len(t.messages) >= maxTrackedMessages {
+			t.addLocked(key, TrafficUnknown, TrafficCounter{Packets: 1, Bytes: size})
+			return
+		}
+		msg = &trafficMessage{pending: make(map[trafficKey]TrafficCounter)}
+		t.messages[hash] = msg
+	}
+	msg.lastActivity = captureTime
+
+	if msg.kind != "" {
+		t.addLocked(key, msg.kind, TrafficCounter{Packets: 1, Bytes: size})
+		return
+	}
+	counter := msg.pending[key]
+	counter.Packets++
+	counter.Bytes += size
+	msg.pending[key] = counter
+}
+
+// ObserveMessage 는 디코딩된 메시지의 종류를 정합니다. UDP 메시지는 모아 둔 청크를 그 종류로 옮기고,
+// TCP 메시지는 메시지 하나를 패킷 하나로 셉니다.
+func (t *TrafficAccounting) ObserveMessage(combined *model.OutboundRouterCombined, meta model.MessageMeta) {
+	kind := classifyTraffic(combined)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if meta.AppMessageHash == "none" {
+		key := trafficKey{ip: meta.SrcIP}
+		if meta.SrcIP != "" && util.IsLocalIP(meta.SrcIP) {
+			key = trafficKey{ip: meta.DstIP, egress: true}
+		}
+		if key.ip == "" {
+			return
+		}
+		t.peerLocked(key.ip, meta.CaptureTime)
+		t.addLocked(key, kind, TrafficCounter{Packets: 1, Bytes: uint64(meta.Size)})
+		return
+	}
+
+	msg, ok := t.messages[meta.AppMessageHash]
+	if !ok || msg.kind != "" {
+		return
+	}
+	msg.kind = kind
+	t.flushLocked(msg)
+}
+
+func (t *TrafficAccounting) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for hash, msg := range t.messages {
+		if now.Sub(msg.lastActivity) < trafficMessageTTL {
+			continue
+		}
+		if msg.kind == "" {
+			msg.kind = TrafficUnknown
+			t.flushLocked(msg)
+		}
+		delete(t.messages, hash)
+	}
+
+	if t.intervalStart.IsZero() {
+		t.intervalStart = now
+		return
+	}
+	if now.Sub(t.intervalStart) < trafficReportInterval {
+		return
+	}
+
+	stats := TrafficStats{
+		IntervalStart: t.intervalStart.UnixMicro(),
+		IntervalEnd:   now.UnixMicro(),
+		In:            newTrafficDirection(),
+		Out:           newTrafficDirection(),
+		Peers:         make([]PeerTraffic, 0, len(t.peers)),
+	}
+	for ip, peer := range t.peers {
+		if now.Sub(peer.lastSeen) > trafficPeerIdleLimit {
+			delete(t.peers, ip)
+			continue
+		}
+		if peer.in.Packets == 0 && peer.out.Packets == 0 {
+			continue
+		}
+		stats.Peers = append(stats.Peers, PeerTraffic{
+			IP:     ip,
+			NodeID: peer.nodeID,
+			In:     peer.in,
+			Out:    peer.out,
+		})
+		mergeTrafficDirection(&stats.In, peer.in)
+		mergeTrafficDirection(&stats.Out, peer.out)
+		peer.in = newTrafficDirection()
+		peer.out = newTrafficDirection()
+	}
+	sort.Slice(stats.Peers, func(i, j int) bool {
+		return stats.Peers[i].In.Bytes+stats.Peers[i].Out.Bytes > stats.Peers[j].In.Bytes+stats.Peers[j].Out.Bytes
+	})
+	t.intervalStart = now
+
+	publisher.Publish(util.TRAFFIC_STATS_EVENT, stats)
+}
+
+func (t *TrafficAccounting) peerLocked(ip string, now time.Time) *peerTraffic {
+	peer, ok := t.peers[ip]
+	if !ok {
+		peer = &peerTraffic{
+			in:  newTrafficDirection(),
+			out: newTrafficDirection(),
+		}
+		t.peers[ip] = peer
+	}
+	peer.lastSeen = now
+	return peer
+}
+
+func (t *TrafficAccounting) flushLocked(msg *trafficMessage) {
+	for key, counter := range msg.pending {
+		t.addLocked(key, msg.kind, counter)
+	}
+	msg.pending = nil
+}
+
+func (t *TrafficAccounting) addLocked(key trafficKey, kind string, counter TrafficCounter) {
+	peer, ok := t.peers[key.ip]
+	if !ok {
+		return
+	}
+	direction := &peer.in
+	if key.egress {
+		direction = &peer.out
+	}
+	direction.Packets += counter.Packets
+	direction.Bytes += counter.Bytes
+	byType := direction.ByType[kind]
+	byType.Packets += counter.Packets
+	byType.Bytes += counter.Bytes
+	direction.ByType[kind] = byType
+}
+
+func newTrafficDirection() TrafficDirection {
+	return TrafficDirection{ByType: make(map[string]TrafficCounter)}
+}
+
+func mergeTrafficDirection(dst *TrafficDirection, src TrafficDirection) {
+	dst.Packets += src.Packets
+	dst.Bytes += src.Bytes
+	for kind, counter := range src.ByType {
+		total := dst.ByType[kind]
+		total.Packets += counter.Packets
+		total.Bytes += counter.Bytes
+		dst.ByType[kind] = total
+	}
+}
+
+func classifyTraffic(combined *model.OutboundRouterCombined) string {
+	if _, ok := combined.PeerDiscovery.(*peer_discovery.PeerDiscoveryMessage); ok {
+		return TrafficDiscovery
+	}
+	if _, ok := combined.FullNodesGroup.(*fullnode_group.FullNodesGroupMessage); ok {
+		return TrafficFullNodeGroup
+	}
+	msg, ok := combined.AppMessage.(*monad.MonadMessage)
+	if !ok {
+		return TrafficUnknown
+	}
+	switch payload := msg.Payload.(type) {
+	case *consensus.ConsensusMessage:
+		protoMsg, ok := payload.Payload.(*protocol.ProtocolMessage)
+		if !ok {
+			return TrafficConsensus
+		}
+		switch protoMsg.Payload.(type) {
+		case *proposal.ProposalMessage:
+			return TrafficProposal
+		case *vote.VoteMessage:
+			return TrafficVote
+		case *timeout.TimeoutMessage:
+			return TrafficTimeout
+		}
+		return TrafficConsensus
+	case *block_sync_request.BlockSyncRequest, *block_sync_response.BlockSyncResponse:
+		return TrafficBlockSync
+	case *state_sync.StateSyncNetworkMessage:
+		return TrafficStateSync
+	case *forwarded_tx.ForwardedTxMessage:
+		return TrafficForwardedTx
+	}
+	return TrafficUnknown
+}
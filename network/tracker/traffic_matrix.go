@@ -0,0 +1,216 @@
+package tracker
+
+import (
+	"encoding/hex"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	matrixReportInterval = 10 * time.Second
+	// 해석하지 못한 첫 홉 수신자는 해시 앞에 이 접두사를 붙여 구분합니다.
+	unresolvedHopPrefix = "hop:"
+)
+
+type TrafficMatrixNode struct {
+	NodeID    string `json:"nodeId"`
+	Validator bool   `json:"validator"`
+	Resolved  bool   `json:"resolved"`
+}
+
+type TrafficMatrixCell struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Chunks uint64 `json:"chunks"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+type TrafficMatrix struct {
+	IntervalStart int64               `json:"intervalStart"`
+	IntervalEnd   int64               `json:"intervalEnd"`
+	Nodes         []TrafficMatrixNode `json:"nodes"`
+	Cells         []TrafficMatrixCell `json:"cells"`
+}
+
+type matrixEdge struct {
+	from string
+	to   string
+}
+
+// matrixChunkKey 는 한 청크를 가리킵니다. 같은 청크의 수신본과 우리 노드의 재전송본은 같은 키를 가집니다.
+type matrixChunkKey struct {
+	hash     [20]byte
+	chunkID  uint16
+	firstHop [20]byte
+}
+
+// TrafficMatrixTracker 는 복구한 청크 서명자에서 FirstHopRecipient 로 가는 청크 수와 바이트를 구간마다 모읍니다.
+// 수신자는 알려진 NodeID 의 해시와 맞춰 보고, 맞는 것이 없으면 해시 그대로 둡니다.
+// 같은 청크가 수신과 재전송으로 여러 번 잡혀도 구간마다 한 번만 셉니다.
+type TrafficMatrixTracker struct {
+	mu            sync.Mutex
+	store         *ValidatorStore
+	storeVersion  uint64
+	hashes        map[[20]byte]string
+	validatorIDs  map[string]bool
+	edges         map[matrixEdge]*TrafficMatrixCell
+	seen          map[matrixChunkKey]bool
+	intervalStart time.Time
+	last          *TrafficMatrix
+}
+
+func NewTrafficMatrixTracker(store *ValidatorStore) *TrafficMatrixTracker {
+	return &TrafficMatrixTracker{
+		store:        store,
+		hashes:       make(map[[20]byte]string),
+		validatorIDs: make(map[string]bool),
+		edges:        make(map[matrixEdge]*TrafficMatrixCell),
+		seen:         make(map[matrixChunkKey]bool),
+	}
+}
+
+func (t *TrafficMatrixTracker) ObserveChunk(chunk *model.MonadChunkPacket, author string, chunkSize int, captureTime time.Time) {
+	if author == "" {
+		return
+	}
+	from := normalizeNodeID(author)
+	key := matrixChunkKey{
+		hash:     chunk.AppMessageHash,
+		chunkID:  chunk.ChunkID,
+		firstHop: chunk.FirstHopRecipient,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.seen[key] {
+		return
+	}
+	t.seen[key] = true
+
+	t.learnLocked(from)
+	to, ok := t.hashes[chunk.FirstHopRecipient]
+	if !ok {
+		to = unresolvedHopPrefix + hex.EncodeToString(chunk.FirstHopRecipient[:])
+	}
+
+	edge := matrixEdge{from: from, to: to}
+	cell, ok := t.edges[edge]
+	if !ok {
+		cell = &TrafficMatrixCell{From: from, To: to}
+		t.edges[edge] = cell
+	}
+	cell.Chunks++
+	cell.Bytes += uint64(chunkSize)
+}
+
+func (t *TrafficMatrixTracker) learnLocked(nodeID string) {
+	hash, ok := util.NodeIDHash(nodeID)
+	if !ok {
+		return
+	}
+	if _, known := t.hashes[hash]; !known {
+		t.hashes[hash] = nodeID
+	}
+}
+
+// refreshValidatorsLocked 는 검증자 파일이 다시 읽혔을 때 모든 에포크의 검증자 해시를 등록합니다.
+func (t *TrafficMatrixTracker) refreshValidatorsLocked() {
+	version := t.store.Version()
+	if version == t.storeVersion {
+		return
+	}
+	t.storeVersion = version
+	t.validatorIDs = make(map[string]bool)
+	for _, epoch := range t.store.Epochs() {
+		set, ok := t.store.Set(epoch)
+		if !ok {
+			continue
+		}
+		for _, v := range set {
+			nodeID := normalizeNodeID(v.NodeID)
+			t.validatorIDs[nodeID] = true
+			t.learnLocked(nodeID)
+		}
+	}
+}
+
+func (t *TrafficMatrixTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.refreshValidatorsLocked()
+
+	if t.intervalStart.IsZero() {
+		t.intervalStart = now
+		return
+	}
+	if now.Sub(t.intervalStart) < matrixReportInterval {
+		return
+	}
+
+	matrix := &TrafficMatrix{
+		IntervalStart: t.intervalStart.UnixMicro(),
+		IntervalEnd:   now.UnixMicro(),
+		Nodes:         []TrafficMatrixNode{},
+		Cells:         make([]TrafficMatrixCell, 0, len(t.edges)),
+	}
+	seen := make(map[string]bool)
+	addNode := func(nodeID string) {
+		if seen[nodeID] {
+			return
+		}
+		seen[nodeID] = true
+		matrix.Nodes = append(matrix.Nodes, TrafficMatrixNode{
+			NodeID:    nodeID,
+			Validator: t.validatorIDs[nodeID],
+			Resolved:  !isUnresolvedHop(nodeID),
+		})
+	}
+	for _, cell := range t.edges {
+		matrix.Cells = append(matrix.Cells, *cell)
+		addNode(cell.From)
+		addNode(cell.To)
+	}
+	sort.Slice(matrix.Nodes, func(i, j int) bool {
+		a, b := matrix.Nodes[i], matrix.Nodes[j]
+		if a.Resolved != b.Resolved {
+			return a.Resolved
+		}
+		return a.NodeID < b.NodeID
+	})
+	sort.Slice(matrix.Cells, func(i, j int) bool {
+		a, b := matrix.Cells[i], matrix.Cells[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	t.edges = make(map[matrixEdge]*TrafficMatrixCell)
+	t.seen = make(map[matrixChunkKey]bool)
+	t.intervalStart = now
+	t.last = matrix
+
+	publisher.Publish(util.TRAFFIC_MATRIX_EVENT, matrix)
+}
+
+// Last 는 마지막으로 발행한 구간의 행렬입니다.
+func (t *TrafficMatrixTracker) Last() (TrafficMatrix, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.last == nil {
+		return TrafficMatrix{}, false
+	}
+	return *t.last, true
+}
+
+func isUnresolvedHop(nodeID string) bool {
+	return strings.HasPrefix(nodeID, unresolvedHopPrefix)
+}
@@ -0,0 +1,271 @@
+package tracker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/model"
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	inferencePublishEvery = 60 * time.Second
+	maxInferredEpochs     = 4
+	maxInferredSigners    = 4096
+	maxIPsPerSigner       = 8
+)
+
+const (
+	ParticipationVote     = "vote"
+	ParticipationProposal = "proposal"
+	ParticipationTimeout  = "timeout"
+)
+
+type InferredValidator struct {
+	NodeID     string   `json:"nodeId"`
+	IPs        []string `json:"ips"`
+	Votes      int      `json:"votes"`
+	Proposals  int      `json:"proposals"`
+	Timeouts   int      `json:"timeouts"`
+	Chunks     int      `json:"chunks"`
+	FirstSeen  int64    `json:"firstSeen"`
+	LastSeen   int64    `json:"lastSeen"`
+	Configured bool     `json:"configured"`
+}
+
+type InferredValidatorSet struct {
+	Epoch      util.Epoch          `json:"epoch"`
+	Validators []InferredValidator `json:"validators"`
+	// 아래는 VALIDATORS_FILE 에 해당 에포크가 있을 때만 채워집니다.
+	HasConfig bool     `json:"hasConfig"`
+	Missing   []string `json:"missing,omitempty"` // 설정에는 있지만 관측되지 않은 노드
+	Unknown   []string `json:"unknown,omitempty"` // 관측되었지만 설정에 없는 노드
+}
+
+type UnknownSigner struct {
+	Epoch  util.Epoch `json:"epoch"`
+	Round  util.Round `json:"round"`
+	NodeID string     `json:"nodeId"`
+	Kind   string     `json:"kind"`
+	SrcIP  string     `json:"srcIp,omitempty"`
+}
+
+type inferredSigner struct {
+	validator InferredValidator
+	ips       map[string]bool
+}
+
+// ValidatorInference 는 투표, 제안, 타임아웃에 서명한 노드로 에포크별 활성 검증자 집합을 추정합니다.
+type ValidatorInference struct {
+	mu          sync.Mutex
+	store       *ValidatorStore
+	epochs      map[util.Epoch]map[string]*inferredSigner
+	flagged     map[util.Epoch]map[string]bool
+	latestEpoch util.Epoch
+	lastPublish time.Time
+}
+
+func NewValidatorInference(store *ValidatorStore) *ValidatorInference {
+	return &ValidatorInference{
+		store:   store,
+		epochs:  make(map[util.Epoch]map[string]*inferredSigner),
+		flagged: make(map[util.Epoch]map[string]bool),
+	}
+}
+
+// ObserveSigner 는 합의 메시지 서명자를 해당 에포크의 참여자로 기록합니다.
+func (v *ValidatorInference) ObserveSigner(epoch util.Epoch, round util.Round, nodeID string, kind string, meta model.MessageMeta) {
+	if epoch == 0 || nodeID == "" {
+		return
+	}
+	nodeID = normalizeNodeID(nodeID)
+	stake, _, hasConfig := v.store.Stake(epoch, nodeID)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	signer := v.signerLocked(epoch, nodeID, meta.CaptureTime)
+	if signer == nil {
+		return
+	}
+	switch kind {
+	case ParticipationVote:
+		signer.validator.Votes++
+	case ParticipationProposal:
+		signer.validator.Proposals++
+	case ParticipationTimeout:
+		signer.validator.Timeouts++
+	}
+	// 투표와 타임아웃은 서명자가 직접 보내므로 출발지 IP 를 서명자의 주소로 봅니다.
+	if kind != ParticipationProposal && meta.SrcIP != "" && !util.IsLocalIP(meta.SrcIP) {
+		signer.addIP(meta.SrcIP)
+	}
+
+	if hasConfig && stake == nil {
+		v.flagLocked(UnknownSigner{
+			Epoch:  epoch,
+			Round:  round,
+			NodeID: nodeID,
+			Kind:   kind,
+			SrcIP:  meta.SrcIP,
+		})
+	}
+}
+
+// ObserveChunk 는 이미 참여자로 확인된 노드의 청크만 셉니다. 풀노드의 청크로 집합이 부풀지 않게 하기 위함입니다.
+func (v *ValidatorInference) ObserveChunk(chunk *model.MonadChunkPacket, author string, captureTime time.Time) {
+	if author == "" {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	signers, ok := v.epochs[util.Epoch(chunk.Epoch)]
+	if !ok {
+		return
+	}
+	signer, ok := signers[author]
+	if !ok {
+		return
+	}
+	signer.validator.Chunks++
+	signer.validator.LastSeen = captureTime.UnixMicro()
+	if src := chunk.Network.Ipv4.SrcIp; !chunk.Broadcast && !chunk.SecondaryBroadcast && src != "" && !util.IsLocalIP(src) {
+		signer.addIP(src)
+	}
+}
+
+func (v *ValidatorInference) signerLocked(epoch util.Epoch, nodeID string, now time.Time) *inferredSigner {
+	if epoch > v.latestEpoch {
+		v.latestEpoch = epoch
+		v.pruneLocked()
+	}
+	signers, ok := v.epochs[epoch]
+	if !ok {
+		if v.latestEpoch >= maxInferredEpochs && epoch <= v.latestEpoch-maxInferredEpochs {
+			return nil
+		}
+		signers = make(map[string]*inferredSigner)
+		v.epochs[epoch] = signers
+	}
+	signer, ok := signers[nodeID]
+	if !ok {
+		if len(signers) >= maxInferredSigners {
+			return nil
+		}
+		signer = &inferredSigner{
+			validator: InferredValidator{NodeID: nodeID, FirstSeen: now.UnixMicro()},
+			ips:       make(map[string]bool),
+		}
+		signers[nodeID] = signer
+	}
+	signer.validator.LastSeen = now.UnixMicro()
+	return signer
+}
+
+func (s *inferredSigner) addIP(ip string) {
+	if s.ips[ip] || len(s.ips) >= maxIPsPerSigner {
+		return
+	}
+	s.ips[ip] = true
+	s.validator.IPs = append(s.validator.IPs, ip)
+}
+
+func (v *ValidatorInference) flagLocked(unknown UnknownSigner) {
+	flagged, ok := v.flagged[unknown.Epoch]
+	if !ok {
+		flagged = make(map[string]bool)
+		v.flagged[unknown.Epoch] = flagged
+	}
+	if flagged[unknown.NodeID] {
+		return
+	}
+	flagged[unknown.NodeID] = true
+	publisher.Publish(util.UNKNOWN_SIGNER_EVENT, unknown)
+}
+
+func (v *ValidatorInference) pruneLocked() {
+	if v.latestEpoch < maxInferredEpochs {
+		return
+	}
+	cutoff := v.latestEpoch - maxInferredEpochs
+	for epoch := range v.epochs {
+		if epoch <= cutoff {
+			delete(v.epochs, epoch)
+		}
+	}
+	for epoch := range v.flagged {
+		if epoch <= cutoff {
+			delete(v.flagged, epoch)
+		}
+	}
+}
+
+func (v *ValidatorInference) setLocked(epoch util.Epoch, configured []util.Validator, hasConfig bool) InferredValidatorSet {
+	set := InferredValidatorSet{Epoch: epoch, HasConfig: hasConfig}
+
+	configuredIDs := make(map[string]bool, len(configured))
+	for _, validator := range configured {
+		configuredIDs[normalizeNodeID(validator.NodeID)] = true
+	}
+
+	signers := v.epochs[epoch]
+	for nodeID, signer := range signers {
+		validator := signer.validator
+		validator.IPs = append([]string(nil), validator.IPs...)
+		validator.Configured = configuredIDs[nodeID]
+		set.Validators = append(set.Validators, validator)
+		if hasConfig && !validator.Configured {
+			set.Unknown = append(set.Unknown, nodeID)
+		}
+	}
+	for nodeID := range configuredIDs {
+		if _, ok := signers[nodeID]; !ok {
+			set.Missing = append(set.Missing, nodeID)
+		}
+	}
+
+	sort.Slice(set.Validators, func(i, j int) bool {
+		return set.Validators[i].NodeID < set.Validators[j].NodeID
+	})
+	sort.Strings(set.Missing)
+	sort.Strings(set.Unknown)
+	return set
+}
+
+// Set 은 추정한 검증자 집합과 설정 파일과의 차이를 돌려줍니다. epoch 가 0 이면 가장 최근 에포크입니다.
+func (v *ValidatorInference) Set(epoch util.Epoch) (InferredValidatorSet, bool) {
+	v.mu.Lock()
+	if epoch == 0 {
+		epoch = v.latestEpoch
+	}
+	_, ok := v.epochs[epoch]
+	v.mu.Unlock()
+	if !ok {
+		return InferredValidatorSet{}, false
+	}
+
+	configured, hasConfig := v.store.Set(epoch)
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.setLocked(epoch, configured, hasConfig), true
+}
+
+func (v *ValidatorInference) Sweep(now time.Time) {
+	v.mu.Lock()
+	if now.Sub(v.lastPublish) < inferencePublishEvery || v.latestEpoch == 0 {
+		v.mu.Unlock()
+		return
+	}
+	v.lastPublish = now
+	epoch := v.latestEpoch
+	v.mu.Unlock()
+
+	if set, ok := v.Set(epoch); ok {
+		publisher.Publish(util.VALIDATOR_INFERENCE_EVENT, set)
+	}
+}
@@ -0,0 +1,187 @@
+package tracker
+
+import (
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const validatorFilePollInterval = 5 * time.Second
+
+type UnknownEpoch struct {
+	Epoch       util.Epoch   `json:"epoch"`
+	Round       util.Round   `json:"round"`
+	SrcIP       string       `json:"srcIp,omitempty"`
+	KnownEpochs []util.Epoch `json:"knownEpochs"`
+}
+
+type epochStakes struct {
+	byNode map[string]*big.Int
+	total  *big.Int
+}
+
+// ValidatorStore 는 VALIDATORS_FILE 의 에포크별 검증자 집합을 보관하고, 파일이 바뀌면 다시 읽습니다.
+// 모든 에포크를 그대로 들고 있으므로 에포크가 앞뒤 어느 방향으로 바뀌어도 조회할 수 있습니다.
+type ValidatorStore struct {
+	mu       sync.RWMutex
+	sets     map[util.Epoch][]util.Validator
+	stakes   map[util.Epoch]epochStakes
+	version  uint64
+	modTime  time.Time
+	size     int64
+	lastPoll time.Time
+	reported map[util.Epoch]bool
+}
+
+func NewValidatorStore() *ValidatorStore {
+	return &ValidatorStore{
+		sets:     make(map[util.Epoch][]util.Validator),
+		stakes:   make(map[util.Epoch]epochStakes),
+		reported: make(map[util.Epoch]bool),
+	}
+}
+
+// Sweep 은 주기적으로 파일의 수정 시각과 크기를 확인해 바뀌었을 때만 다시 읽습니다.
+func (s *ValidatorStore) Sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastPoll) < validatorFilePollInterval {
+		return
+	}
+	s.lastPoll = now
+	s.reloadLocked()
+}
+
+func (s *ValidatorStore) reloadLocked() {
+	path := util.GetValidatorsFilePath()
+	if path == "" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		log.Printf("[Validators] Failed to stat %s: %v", path, err)
+		return
+	}
+	if s.version != 0 && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return
+	}
+
+	config, err := util.LoadValidatorsConfig()
+	if err != nil {
+		// 잘못된 파일로 바뀌어도 마지막으로 읽은 집합은 유지합니다.
+		log.Printf("[Validators] Failed to load validators config, keeping previous sets: %v", err)
+		return
+	}
+
+	sets := make(map[util.Epoch][]util.Validator, len(config.ValidatorSets))
+	stakes := make(map[util.Epoch]epochStakes, len(config.ValidatorSets))
+	for _, vSet := range config.ValidatorSets {
+		epoch := util.Epoch(vSet.Epoch)
+		sets[epoch] = vSet.Validators
+		stakes[epoch] = buildEpochStakes(vSet.Validators)
+	}
+
+	s.sets = sets
+	s.stakes = stakes
+	s.modTime = info.ModTime()
+	s.size = info.Size()
+	s.version++
+	s.reported = make(map[util.Epoch]bool)
+	log.Printf("[Validators] Loaded %d validator sets from %s (epochs %v)", len(sets), path, s.epochsLocked())
+}
+
+func buildEpochStakes(validators []util.Validator) epochStakes {
+	stakes := epochStakes{
+		byNode: make(map[string]*big.Int, len(validators)),
+		total:  new(big.Int),
+	}
+	for _, v := range validators {
+		stake, ok := new(big.Int).SetString(v.Stake, 0)
+		if !ok {
+			continue
+		}
+		stakes.byNode[normalizeNodeID(v.NodeID)] = stake
+		stakes.total.Add(stakes.total, stake)
+	}
+	return stakes
+}
+
+// ensureLoaded 는 Sweep 이 돌기 전에 들어온 첫 조회에서 파일을 읽습니다.
+func (s *ValidatorStore) ensureLoaded() {
+	s.mu.RLock()
+	loaded := s.version != 0 || !s.lastPoll.IsZero()
+	s.mu.RUnlock()
+	if !loaded {
+		s.Sweep(time.Now())
+	}
+}
+
+func (s *ValidatorStore) Set(epoch util.Epoch) ([]util.Validator, bool) {
+	s.ensureLoaded()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	validators, ok := s.sets[epoch]
+	return validators, ok
+}
+
+// Stake 는 노드의 지분과 에포크 전체 지분을 돌려줍니다. 집합에 없는 노드는 stake 가 nil 입니다.
+func (s *ValidatorStore) Stake(epoch util.Epoch, nodeID string) (stake *big.Int, total *big.Int, ok bool) {
+	s.ensureLoaded()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stakes, ok := s.stakes[epoch]
+	if !ok {
+		return nil, nil, false
+	}
+	return stakes.byNode[normalizeNodeID(nodeID)], stakes.total, true
+}
+
+// Version 은 파일을 다시 읽을 때마다 증가합니다. 파생 캐시의 무효화에 씁니다.
+func (s *ValidatorStore) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+func (s *ValidatorStore) Epochs() []util.Epoch {
+	s.ensureLoaded()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.epochsLocked()
+}
+
+func (s *ValidatorStore) epochsLocked() []util.Epoch {
+	epochs := make([]util.Epoch, 0, len(s.sets))
+	for epoch := range s.sets {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+	return epochs
+}
+
+// ReportUnknown 은 검증자 집합이 없는 에포크를 참조한 메시지를 파일 버전마다 한 번씩 알립니다.
+func (s *ValidatorStore) ReportUnknown(epoch util.Epoch, round util.Round, srcIP string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sets[epoch]; ok || s.reported[epoch] {
+		return
+	}
+	s.reported[epoch] = true
+
+	known := s.epochsLocked()
+	log.Printf("[Validators] No validator set for epoch %d (round %d); known epochs %v", epoch, round, known)
+	publisher.Publish(util.UNKNOWN_EPOCH_EVENT, UnknownEpoch{
+		Epoch:       epoch,
+		Round:       round,
+		SrcIP:       srcIP,
+		KnownEpochs: known,
+	})
+}
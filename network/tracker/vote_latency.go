@@ -0,0 +1,108 @@
+package tracker
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"monad-flow/publisher"
+	"monad-flow/util"
+)
+
+const (
+	voteLatencyWindow       = 512
+	voteLatencyPublishEvery = 30 * time.Second
+	// 이 시간 동안 투표가 없던 투표자는 통계에서 뺍니다.
+	voterIdleTimeout = 10 * time.Minute
+)
+
+type VoterLatencyStats struct {
+	Voter     string     `json:"voter"`
+	Samples   int        `json:"samples"`
+	P50Ms     float64    `json:"p50Ms"`
+	P90Ms     float64    `json:"p90Ms"`
+	P99Ms     float64    `json:"p99Ms"`
+	LastRound util.Round `json:"lastRound"`
+}
+
+type voterState struct {
+	window    *rollingWindow
+	lastRound util.Round
+	lastSeen  time.Time
+}
+
+// VoteLatencyTracker 는 마감된 라운드의 투표 지연을 투표자별 롤링 윈도우에 모읍니다.
+type VoteLatencyTracker struct {
+	mu          sync.Mutex
+	voters      map[string]*voterState
+	lastPublish time.Time
+}
+
+func NewVoteLatencyTracker() *VoteLatencyTracker {
+	return &VoteLatencyTracker{
+		voters: make(map[string]*voterState),
+	}
+}
+
+func (t *VoteLatencyTracker) ObserveRound(tl RoundTimeline) {
+	if len(tl.VoteLatencies) == 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	for _, vote := range tl.VoteLatencies {
+		state, ok := t.voters[vote.Voter]
+		if !ok {
+			state = &voterState{window: newRollingWindow(voteLatencyWindow)}
+			t.voters[vote.Voter] = state
+		}
+		state.window.Add(vote.LatencyMs)
+		state.lastRound = tl.Round
+		state.lastSeen = now
+	}
+}
+
+func (t *VoteLatencyTracker) snapshotLocked() []VoterLatencyStats {
+	stats := make([]VoterLatencyStats, 0, len(t.voters))
+	for voter, state := range t.voters {
+		stats = append(stats, VoterLatencyStats{
+			Voter:     voter,
+			Samples:   state.window.Len(),
+			P50Ms:     state.window.Percentile(50),
+			P90Ms:     state.window.Percentile(90),
+			P99Ms:     state.window.Percentile(99),
+			LastRound: state.lastRound,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].P50Ms > stats[j].P50Ms
+	})
+	return stats
+}
+
+// Snapshot 은 투표자별 지연 백분위수를 중앙값이 큰 순서로 돌려줍니다.
+func (t *VoteLatencyTracker) Snapshot() []VoterLatencyStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshotLocked()
+}
+
+func (t *VoteLatencyTracker) Sweep(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for voter, state := range t.voters {
+		if now.Sub(state.lastSeen) >= voterIdleTimeout {
+			delete(t.voters, voter)
+		}
+	}
+
+	if now.Sub(t.lastPublish) < voteLatencyPublishEvery || len(t.voters) == 0 {
+		return
+	}
+	t.lastPublish = now
+	publisher.Publish(util.VOTE_LATENCY_EVENT, t.snapshotLocked())
+}
@@ -0,0 +1,49 @@
+package tracker
+
+import "sort"
+
+// rollingWindow 는 최근 size 개의 샘플만 유지하며 백분위수를 계산합니다.
+type rollingWindow struct {
+	samples []float64
+	next    int
+	size    int
+}
+
+func newRollingWindow(size int) *rollingWindow {
+	return &rollingWindow{
+		samples: make([]float64, 0, size),
+		size:    size,
+	}
+}
+
+func (w *rollingWindow) Add(v float64) {
+	if len(w.samples) < w.size {
+		w.samples = append(w.samples, v)
+		return
+	}
+	w.samples[w.next] = v
+	w.next = (w.next + 1) % w.size
+}
+
+func (w *rollingWindow) Len() int {
+	return len(w.samples)
+}
+
+// Percentile 은 p (0~100) 백분위수를 nearest-rank 방식으로 돌려줍니다. 샘플이 없으면 0 입니다.
+func (w *rollingWindow) Percentile(p float64) float64 {
+	if len(w.samples) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(w.samples))
+	copy(sorted, w.samples)
+	sort.Float64s(sorted)
+
+	idx := int(p/100*float64(len(sorted))+0.5) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
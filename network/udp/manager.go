@@ -12,6 +12,7 @@ import (
 	"monad-flow/decoder"
 	"monad-flow/model"
 	"monad-flow/parser"
+	"monad-flow/tracker"
 	"monad-flow/util"
 
 	probing "github.com/prometheus-community/pro-bing"
@@ -132,11 +133,16 @@ func (m *Manager) processChunk(
 		m.monitorLatency(destinationIp)
 	}
 
+	var author string
 	senderInfo, err := util.RecoverSenderHybrid(chunk, chunkData)
 	if err != nil {
 		log.Printf("[Recovery-Warn] Failed to recover sender: %v", err)
+	} else {
+		author = senderInfo.NodeID
 	}
 
+	tracker.ObserveChunk(chunk, author, len(chunkData), captureTime)
+
 	jsonData, err := json.Marshal(chunk)
 	if err != nil {
 		log.Printf("JSON marshaling failed: %s", err)
@@ -147,7 +153,7 @@ func (m *Manager) processChunk(
 		"type":        util.MONAD_CHUNK_PACKET_EVENT,
 		"data":        json.RawMessage(jsonData),
 		"timestamp":   captureTime.UnixMicro(),
-		"secp_pubkey": senderInfo.NodeID,
+		"secp_pubkey": author,
 	}
 
 	decodedMsg, err := m.decoderCache.HandleChunk(chunk)
@@ -158,8 +164,15 @@ func (m *Manager) processChunk(
 	}
 
 	if decodedMsg != nil {
-		appMessageHash := fmt.Sprintf("0x%x", decodedMsg.AppMessageHash)
-		if err := parser.HandleDecodedMessage(decodedMsg.Data, appMessageHash); err != nil {
+		meta := model.MessageMeta{
+			AppMessageHash: fmt.Sprintf("0x%x", decodedMsg.AppMessageHash),
+			SrcIP:          sourceIp,
+			DstIP:          destinationIp,
+			Author:         author,
+			CaptureTime:    captureTime,
+			Size:           len(decodedMsg.Data),
+		}
+		if err := parser.HandleDecodedMessage(decodedMsg.Data, meta); err != nil {
 			log.Printf("[RLP-ERROR] Failed to decode message: %v", err)
 		}
 	}
@@ -210,6 +223,7 @@ func (m *Manager) sendPing(ip string) {
 
 	stats := pinger.Statistics()
 	if stats.PacketsRecv > 0 {
+		tracker.ObservePing(ip, stats.AvgRtt)
 		m.wsChan <- map[string]interface{}{
 			"type":      util.PING_LATENCY_EVENT,
 			"ip":        ip,
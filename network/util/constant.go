@@ -3,14 +3,43 @@ package util
 // --- 상수 테이블 (Constant Table) ---
 
 const (
-	MONAD_CHUNK_EVENT = "MONAD_CHUNK"
-	PING_EVENT        = "PING"
+	MONAD_CHUNK_EVENT    = "MONAD_CHUNK"
+	PING_EVENT           = "PING"
+	MONAD_ANALYSIS_EVENT = "MONAD_ANALYSIS"
 )
 
 const (
-	MONAD_CHUNK_PACKET_EVENT = 0
-	OUTBOUND_ROUTER_EVENT    = 1
-	PING_LATENCY_EVENT       = 2
+	MONAD_CHUNK_PACKET_EVENT   = 0
+	OUTBOUND_ROUTER_EVENT      = 1
+	PING_LATENCY_EVENT         = 2
+	BLOCK_SYNC_EVENT           = 3
+	BLOCK_SYNC_CATCHUP_EVENT   = 4
+	STATE_SYNC_SESSION_EVENT   = 5
+	PEER_TABLE_EVENT           = 6
+	NAME_RECORD_ALERT_EVENT    = 7
+	FULLNODE_GROUP_EVENT       = 8
+	BLOCK_BODY_MISMATCH_EVENT  = 9
+	ROUND_TIMELINE_EVENT       = 10
+	LEADER_MISMATCH_EVENT      = 11
+	LEADER_SCORE_EVENT         = 12
+	VOTE_LATENCY_EVENT         = 13
+	EXECUTION_DELAY_EVENT      = 14
+	EQUIVOCATION_EVENT         = 15
+	ORPHAN_BLOCK_EVENT         = 16
+	BLOCK_COMMIT_EVENT         = 17
+	TIMEOUT_ANALYSIS_EVENT     = 18
+	UNKNOWN_EPOCH_EVENT        = 19
+	UNKNOWN_SIGNER_EVENT       = 20
+	VALIDATOR_INFERENCE_EVENT  = 21
+	PROPAGATION_STATS_EVENT    = 22
+	PROPOSAL_PROPAGATION_EVENT = 23
+	BLOCK_METRICS_EVENT        = 24
+	EXECUTION_DIVERGENCE_EVENT = 25
+	SELF_METRICS_EVENT         = 26
+	RELAY_LATENCY_EVENT        = 27
+	CHAIN_STALL_EVENT          = 28
+	TRAFFIC_STATS_EVENT        = 29
+	TRAFFIC_MATRIX_EVENT       = 30
 )
 
 const (
@@ -52,10 +81,11 @@ const (
 	HeaderSize    = 16
 	SignatureSize = 65 // L2: 65바이트 서명
 
-	MerkleHashLen      = 20
-	HeaderFullLen      = 108
-	HeaderSansSigLen   = 43 // 108 - 65
-	MonadSigningPrefix = "\x19monad/raptorcast-chunk/1\n"
+	MerkleHashLen           = 20
+	HeaderFullLen           = 108
+	HeaderSansSigLen        = 43 // 108 - 65
+	MonadSigningPrefix      = "\x19monad/raptorcast-chunk/1\n"
+	NameRecordSigningPrefix = "\x19monad/name-record/1\n"
 
 	BlockSyncReqMsgName       = "BlockSyncRequestMessage"
 	BlockSyncResMsgName       = "BlockSyncResponseMessage"
@@ -112,6 +142,11 @@ const (
 	PeerDiscoveryVersion uint16 = 1
 )
 
+const (
+	NameRecordV1 uint8 = 1
+	NameRecordV2 uint8 = 2
+)
+
 const (
 	GroupMsgVersion uint8 = 1
 )
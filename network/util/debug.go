@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 )
 
 func ApplicationHexDump(data []byte) {
@@ -47,14 +49,43 @@ func ApplicationHexDump(data []byte) {
 	fmt.Println("-----------------------------------------------------------------")
 }
 
+const localIPRefreshInterval = 30 * time.Second
+
+var localIPs struct {
+	mu        sync.RWMutex
+	set       map[string]bool
+	refreshed time.Time
+}
+
+// IsLocalIP 는 주어진 주소가 이 호스트의 인터페이스 주소인지 확인합니다.
+// 청크마다 불리므로 인터페이스 목록은 localIPRefreshInterval 동안 캐시합니다.
 func IsLocalIP(ipStr string) bool {
 	if ipStr == "127.0.0.1" || ipStr == "::1" || ipStr == "localhost" {
 		return true
 	}
 
+	localIPs.mu.RLock()
+	fresh := time.Since(localIPs.refreshed) < localIPRefreshInterval
+	local := localIPs.set[ipStr]
+	localIPs.mu.RUnlock()
+	if fresh {
+		return local
+	}
+
+	localIPs.mu.Lock()
+	defer localIPs.mu.Unlock()
+	if time.Since(localIPs.refreshed) >= localIPRefreshInterval {
+		localIPs.set = loadLocalIPs()
+		localIPs.refreshed = time.Now()
+	}
+	return localIPs.set[ipStr]
+}
+
+func loadLocalIPs() map[string]bool {
+	set := make(map[string]bool)
 	interfaces, err := net.InterfaceAddrs()
 	if err != nil {
-		return false
+		return set
 	}
 
 	for _, addr := range interfaces {
@@ -66,9 +97,9 @@ func IsLocalIP(ipStr string) bool {
 			ip = v.IP
 		}
 
-		if ip != nil && ip.String() == ipStr {
-			return true
+		if ip != nil {
+			set[ip.String()] = true
 		}
 	}
-	return false
+	return set
 }
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/zeebo/blake3"
+)
+
+// HashEncoded 는 monad-bft 의 HasherType 과 같은 방식(blake3-256)으로 RLP 인코딩된 객체를 해시합니다.
+// ConsensusBlockHeader 의 BlockID, ConsensusBlockBody 의 BodyID 가 이 값입니다.
+func HashEncoded(encoded []byte) common.Hash {
+	return common.Hash(blake3.Sum256(encoded))
+}
+
+// NodeIDHash 는 청크의 FirstHopRecipient 와 비교할 수 있도록 NodeID(압축 secp 공개키)를 blake3-256 으로 해시해 앞 20바이트를 돌려줍니다.
+func NodeIDHash(nodeID string) ([20]byte, bool) {
+	var out [20]byte
+	pubkey, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(nodeID), "0x"))
+	if err != nil || len(pubkey) != 33 {
+		return out, false
+	}
+	sum := blake3.Sum256(pubkey)
+	copy(out[:], sum[:20])
+	return out, true
+}
@@ -0,0 +1,22 @@
+package util
+
+import "testing"
+
+func TestHashEncoded(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded []byte
+		want    string
+	}{
+		// blake3-256 공개 테스트 벡터
+		{name: "empty input", encoded: nil, want: "0xaf1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+		{name: "single zero byte", encoded: []byte{0}, want: "0x2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HashEncoded(tt.encoded).Hex(); got != tt.want {
+				t.Fatalf("HashEncoded() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package util
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+// LocalIdentity 는 사이드카가 옆에서 돌고 있는 노드의 공개키입니다. 모르면 빈 값입니다.
+type LocalIdentity struct {
+	SecpPubkey string `json:"secpPubkey,omitempty"` // 압축 secp 공개키 (NodeID)
+	BLSPubkey  string `json:"blsPubkey,omitempty"`
+}
+
+// 공개키 종류별로 파일에서 찾을 키 이름과 16진수 길이입니다.
+type pubkeyKind struct {
+	names  []string
+	hexLen int
+}
+
+var (
+	// 압축 secp256k1 공개키는 33바이트입니다.
+	secpPubkeyKind = pubkeyKind{names: []string{"secp_pubkey", "node_id", "secp"}, hexLen: 66}
+	// BLS12-381 G1 압축 공개키는 48바이트입니다.
+	blsPubkeyKind = pubkeyKind{names: []string{"bls_pubkey", "cert_pubkey", "bls"}, hexLen: 96}
+)
+
+// GetLocalIdentity 는 LOCAL_SECP_PUBKEY / LOCAL_BLS_PUBKEY 를 읽고, 없으면
+// LOCAL_SECP_PUBKEY_FILE / LOCAL_BLS_PUBKEY_FILE 이 가리키는 파일에서 공개키를 읽습니다.
+// 형식이 맞지 않는 키는 추측하지 않고 버립니다.
+func GetLocalIdentity() LocalIdentity {
+	godotenv.Load()
+
+	return LocalIdentity{
+		SecpPubkey: readPubkey("LOCAL_SECP_PUBKEY", secpPubkeyKind),
+		BLSPubkey:  readPubkey("LOCAL_BLS_PUBKEY", blsPubkeyKind),
+	}
+}
+
+func readPubkey(envKey string, kind pubkeyKind) string {
+	if value := os.Getenv(envKey); value != "" {
+		pubkey := normalizePubkey(value)
+		if err := kind.validate(pubkey); err != nil {
+			log.Printf("Ignoring %s: %v", envKey, err)
+			return ""
+		}
+		return pubkey
+	}
+
+	path := os.Getenv(envKey + "_FILE")
+	if path == "" {
+		return ""
+	}
+	pubkey, err := readPubkeyFile(path, kind)
+	if err != nil {
+		log.Printf("Failed to read %s from %s: %v", envKey, path, err)
+		return ""
+	}
+	return pubkey
+}
+
+// readPubkeyFile 은 키 한 줄만 있는 파일과, monad 노드 설정처럼 `secp_pubkey = "0x..."` 형태의
+// 줄이 있는 파일을 받습니다. `key = value` 줄은 kind 의 키 이름과 일치할 때만 사용하고,
+// 값은 kind 의 길이를 만족해야 합니다. 암호화된 keystore JSON 은 지원하지 않습니다.
+func readPubkeyFile(path string, kind pubkeyKind) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "{") {
+		return "", fmt.Errorf("encrypted keystore JSON is not supported, provide the public key instead")
+	}
+
+	var bare []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if idx := strings.Index(line, "="); idx >= 0 {
+			name := strings.ToLower(strings.TrimSpace(line[:idx]))
+			if !kind.matches(name) {
+				continue
+			}
+			pubkey := normalizePubkey(strings.Trim(strings.TrimSpace(line[idx+1:]), `"'`))
+			if err := kind.validate(pubkey); err != nil {
+				return "", fmt.Errorf("%s: %w", name, err)
+			}
+			return pubkey, nil
+		}
+		bare = append(bare, normalizePubkey(strings.Trim(line, `"'`)))
+	}
+
+	// 이름 붙은 키가 없으면 키 한 줄만 있는 파일로 봅니다.
+	if len(bare) != 1 {
+		return "", fmt.Errorf("no %s entry and not a single-key file", strings.Join(kind.names, "/"))
+	}
+	if err := kind.validate(bare[0]); err != nil {
+		return "", err
+	}
+	return bare[0], nil
+}
+
+func (k pubkeyKind) matches(name string) bool {
+	for _, n := range k.names {
+		if name == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (k pubkeyKind) validate(pubkey string) error {
+	if len(pubkey) != k.hexLen || !isHex(pubkey) {
+		return fmt.Errorf("expected %d hex characters, got %q", k.hexLen, pubkey)
+	}
+	return nil
+}
+
+func normalizePubkey(value string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "0x")
+}
+
+func isHex(value string) bool {
+	if value == "" || len(value)%2 != 0 {
+		return false
+	}
+	for _, c := range value {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
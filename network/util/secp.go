@@ -64,14 +64,23 @@ func RecoverSenderHybrid(chunk *model.MonadChunkPacket, rawData []byte) (*Sender
 	msgPayload = append(msgPayload, headerBody...)
 	msgPayload = append(msgPayload, computedRoot...)
 
-	signingInput := make([]byte, 0, len(MonadSigningPrefix)+len(msgPayload))
-	signingInput = append(signingInput, []byte(MonadSigningPrefix)...)
-	signingInput = append(signingInput, msgPayload...)
+	return RecoverSigner(MonadSigningPrefix, msgPayload, chunk.Signature[:])
+}
+
+// RecoverSigner 는 signing domain prefix 가 붙은 메시지의 blake3 해시로부터 secp 서명자를 복구합니다.
+func RecoverSigner(domainPrefix string, msg []byte, sig []byte) (*SenderInfo, error) {
+	if len(sig) != SignatureSize {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	signingInput := make([]byte, 0, len(domainPrefix)+len(msg))
+	signingInput = append(signingInput, []byte(domainPrefix)...)
+	signingInput = append(signingInput, msg...)
 
 	sighash := blake3.Sum256(signingInput)
 
 	signature := make([]byte, 65)
-	copy(signature, chunk.Signature[:])
+	copy(signature, sig)
 
 	if signature[64] >= 27 {
 		signature[64] -= 27
@@ -0,0 +1,168 @@
+package util
+
+import (
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/joho/godotenv"
+)
+
+const (
+	DefaultChainID  = 143
+	topSendersLimit = 5
+)
+
+// 수수료 히스토그램 버킷 상한 (gwei, maxFeePerGas 기준). 마지막 버킷은 상한 없음.
+var feeBucketBoundsGwei = []uint64{1, 2, 5, 10, 20, 50, 100, 200, 500}
+
+var (
+	txSigner     types.Signer
+	txSignerOnce sync.Once
+)
+
+type TxSummary struct {
+	Hash                 string `json:"hash"`
+	From                 string `json:"from,omitempty"`
+	Type                 uint8  `json:"type"`
+	Nonce                uint64 `json:"nonce"`
+	GasLimit             uint64 `json:"gasLimit"`
+	GasPrice             string `json:"gasPrice"`
+	MaxFeePerGas         string `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+	To                   string `json:"to,omitempty"`
+	Value                string `json:"value"`
+	Selector             string `json:"selector,omitempty"`
+}
+
+type FeeBucket struct {
+	UpperGwei uint64 `json:"upperGwei"` // 0 이면 상한 없음
+	Count     int    `json:"count"`
+}
+
+type SenderCount struct {
+	From  string `json:"from"`
+	Count int    `json:"count"`
+}
+
+type TxAggregate struct {
+	TxCount       int           `json:"txCount"`
+	TotalGasLimit uint64        `json:"totalGasLimit"`
+	FeeHistogram  []FeeBucket   `json:"feeHistogram"`
+	TopSenders    []SenderCount `json:"topSenders"`
+}
+
+type TxBatchSummary struct {
+	Transactions []TxSummary `json:"transactions"`
+	Aggregate    TxAggregate `json:"aggregate"`
+}
+
+func GetChainID() *big.Int {
+	godotenv.Load()
+
+	chainIDStr := os.Getenv("CHAIN_ID")
+	if chainIDStr == "" {
+		return big.NewInt(DefaultChainID)
+	}
+
+	chainID, err := strconv.ParseUint(chainIDStr, 10, 64)
+	if err != nil {
+		log.Printf("Invalid CHAIN_ID value: %s, using default %d", chainIDStr, DefaultChainID)
+		return big.NewInt(DefaultChainID)
+	}
+	return new(big.Int).SetUint64(chainID)
+}
+
+func getTxSigner() types.Signer {
+	txSignerOnce.Do(func() {
+		txSigner = types.LatestSignerForChainID(GetChainID())
+	})
+	return txSigner
+}
+
+func SummarizeTx(tx *types.Transaction) TxSummary {
+	summary := TxSummary{
+		Hash:                 tx.Hash().Hex(),
+		Type:                 tx.Type(),
+		Nonce:                tx.Nonce(),
+		GasLimit:             tx.Gas(),
+		GasPrice:             tx.GasPrice().String(),
+		MaxFeePerGas:         tx.GasFeeCap().String(),
+		MaxPriorityFeePerGas: tx.GasTipCap().String(),
+		Value:                tx.Value().String(),
+	}
+
+	if from, err := types.Sender(getTxSigner(), tx); err == nil {
+		summary.From = from.Hex()
+	}
+	if to := tx.To(); to != nil {
+		summary.To = to.Hex()
+	}
+	if data := tx.Data(); len(data) >= 4 {
+		summary.Selector = fmt.Sprintf("0x%x", data[:4])
+	}
+	return summary
+}
+
+func SummarizeTransactions(txs []*types.Transaction) *TxBatchSummary {
+	batch := &TxBatchSummary{
+		Transactions: make([]TxSummary, 0, len(txs)),
+		Aggregate: TxAggregate{
+			FeeHistogram: make([]FeeBucket, len(feeBucketBoundsGwei)+1),
+		},
+	}
+	for i, bound := range feeBucketBoundsGwei {
+		batch.Aggregate.FeeHistogram[i].UpperGwei = bound
+	}
+
+	senderCounts := make(map[string]int)
+	for _, tx := range txs {
+		if tx == nil {
+			continue
+		}
+		summary := SummarizeTx(tx)
+		batch.Transactions = append(batch.Transactions, summary)
+
+		batch.Aggregate.TxCount++
+		batch.Aggregate.TotalGasLimit += summary.GasLimit
+		batch.Aggregate.FeeHistogram[feeBucketIndex(tx.GasFeeCap())].Count++
+		if summary.From != "" {
+			senderCounts[summary.From]++
+		}
+	}
+
+	batch.Aggregate.TopSenders = topSenders(senderCounts, topSendersLimit)
+	return batch
+}
+
+func feeBucketIndex(feeCap *big.Int) int {
+	gwei := new(big.Int).Div(feeCap, big.NewInt(1e9))
+	for i, bound := range feeBucketBoundsGwei {
+		if gwei.Cmp(new(big.Int).SetUint64(bound)) < 0 {
+			return i
+		}
+	}
+	return len(feeBucketBoundsGwei)
+}
+
+func topSenders(counts map[string]int, limit int) []SenderCount {
+	senders := make([]SenderCount, 0, len(counts))
+	for from, count := range counts {
+		senders = append(senders, SenderCount{From: from, Count: count})
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		if senders[i].Count != senders[j].Count {
+			return senders[i].Count > senders[j].Count
+		}
+		return senders[i].From < senders[j].From
+	})
+	if len(senders) > limit {
+		senders = senders[:limit]
+	}
+	return senders
+}
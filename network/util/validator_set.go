@@ -23,11 +23,13 @@ type ValidatorsConfig struct {
 	ValidatorSets []ValidatorSet `toml:"validator_sets"`
 }
 
-func LoadValidatorsConfig() (*ValidatorsConfig, error) {
-	if err := godotenv.Load(); err != nil {
-	}
+func GetValidatorsFilePath() string {
+	godotenv.Load()
+	return os.Getenv("VALIDATORS_FILE")
+}
 
-	filePath := os.Getenv("VALIDATORS_FILE")
+func LoadValidatorsConfig() (*ValidatorsConfig, error) {
+	filePath := GetValidatorsFilePath()
 	if filePath == "" {
 		return nil, fmt.Errorf("VALIDATORS_FILE environment variable not set")
 	}